@@ -16,18 +16,19 @@ package clickhousetracesexporter
 
 import (
 	"context"
-	"crypto/md5"
-	"encoding/json"
-	"fmt"
+	"io"
 	"net/url"
-	"strconv"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/model/pdata"
-	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signoz"
 )
 
 // Crete new exporter.
@@ -35,9 +36,24 @@ func newExporter(cfg config.Exporter, logger *zap.Logger) (*storage, error) {
 
 	configClickHouse := cfg.(*Config)
 
+	errorMatcher, err := signoz.NewErrorMatcher(configClickHouse.HasError)
+	if err != nil {
+		return nil, err
+	}
+
 	f := ClickHouseNewFactory(configClickHouse.Migrations, configClickHouse.Datasource)
+	f.Options.getPrimary().TimeColumnSource = configClickHouse.TimeColumnSource
+	if configClickHouse.WriteSpanEvents {
+		f.Options.getPrimary().EventsTable = defaultEventsTable
+	}
+	if configClickHouse.WriteSpanLinks {
+		f.Options.getPrimary().LinksTable = defaultLinksTable
+	}
+	if configClickHouse.WriteExceptionLogs {
+		f.Options.getPrimary().ExceptionLogsTable = defaultExceptionLogsTable
+	}
 
-	err := f.Initialize(logger)
+	err = f.Initialize(logger)
 	if err != nil {
 		return nil, err
 	}
@@ -46,257 +62,121 @@ func newExporter(cfg config.Exporter, logger *zap.Logger) (*storage, error) {
 	if err != nil {
 		return nil, err
 	}
-	storage := storage{Writer: spanWriter}
+	// The hostname is best effort, the instance ID tells apart collectors sharing one.
+	hostname, _ := os.Hostname()
+	storage := storage{
+		Writer:                     spanWriter,
+		collectorHostname:          hostname,
+		collectorInstanceID:        strings.Replace(uuid.New().String(), "-", "", -1),
+		errorMatcher:               errorMatcher,
+		sanitizeURLs:               configClickHouse.SanitizeURLs,
+		serializeComplexAttributes: configClickHouse.SerializeComplexAttributes,
+		omitIndexEvents:            configClickHouse.OmitIndexEvents,
+		clockSkewThreshold:         configClickHouse.ClockSkew.Threshold,
+		writeErrors:                newWriteErrorLogger(logger),
+	}
+	if configClickHouse.Dedup.Window > 0 {
+		storage.dedup = newSpanDeduplicator(configClickHouse.Dedup)
+	}
+	if configClickHouse.LateSpans.enabled() {
+		storage.lateSpans = newLateSpanPolicy(configClickHouse.LateSpans)
+	}
+	if configClickHouse.ErrorRateLimit.MaxPerGroup > 0 {
+		storage.errorLimiter = newErrorLimiter(configClickHouse.ErrorRateLimit, logger)
+	}
+	if configClickHouse.ServiceCatalog.enabled() {
+		storage.catalog = newServiceCatalog(configClickHouse.ServiceCatalog, logger)
+	}
 
 	return &storage, nil
 }
 
 type storage struct {
-	Writer Writer
+	Writer       Writer
+	errorMatcher *signoz.ErrorMatcher
+	sanitizeURLs bool
+	catalog      *serviceCatalog
+	errorLimiter *errorLimiter
+	lateSpans    *lateSpanPolicy
+	dedup        *spanDeduplicator
+	writeErrors  *writeErrorLogger
+	// serializeComplexAttributes keeps array and map attributes in tagMap as JSON.
+	serializeComplexAttributes bool
+	// omitIndexEvents keeps the events in the trace model only.
+	omitIndexEvents bool
+	// clockSkewThreshold enables the clock skew correction when positive.
+	clockSkewThreshold time.Duration
+	// collectorHostname and collectorInstanceID identify the collector writing the rows.
+	collectorHostname   string
+	collectorInstanceID string
 }
 
-func makeJaegerProtoReferences(
-	links pdata.SpanLinkSlice,
-	parentSpanID pdata.SpanID,
-	traceID pdata.TraceID,
-) ([]OtelSpanRef, error) {
-
-	parentSpanIDSet := len(parentSpanID.Bytes()) != 0
-	if !parentSpanIDSet && links.Len() == 0 {
-		return nil, nil
-	}
-
-	refsCount := links.Len()
-	if parentSpanIDSet {
-		refsCount++
+func (s *storage) start(ctx context.Context, _ component.Host) error {
+	if s.catalog != nil {
+		s.catalog.start(ctx)
 	}
+	return nil
+}
 
-	refs := make([]OtelSpanRef, 0, refsCount)
-
-	// Put parent span ID at the first place because usually backends look for it
-	// as the first CHILD_OF item in the model.SpanRef slice.
-	if parentSpanIDSet {
-
-		refs = append(refs, OtelSpanRef{
-			TraceId: traceID.HexString(),
-			SpanId:  parentSpanID.HexString(),
-			RefType: "CHILD_OF",
-		})
+func (s *storage) shutdown(context.Context) error {
+	if s.catalog != nil {
+		s.catalog.shutdown()
 	}
-
-	for i := 0; i < links.Len(); i++ {
-		link := links.At(i)
-
-		refs = append(refs, OtelSpanRef{
-			TraceId: link.TraceID().HexString(),
-			SpanId:  link.SpanID().HexString(),
-
-			// Since Jaeger RefType is not captured in internal data,
-			// use SpanRefType_FOLLOWS_FROM by default.
-			// SpanRefType_CHILD_OF supposed to be set only from parentSpanID.
-			RefType: "FOLLOWS_FROM",
-		})
+	if closer, ok := s.Writer.(io.Closer); ok {
+		return closer.Close()
 	}
-
-	return refs, nil
+	return nil
 }
 
-// ServiceNameForResource gets the service name for a specified Resource.
-// TODO: Find a better package for this function.
-func ServiceNameForResource(resource pdata.Resource) string {
-	// if resource.IsNil() {
-	// 	return "<nil-resource>"
-	// }
-
-	service, found := resource.Attributes().Get(conventions.AttributeServiceName)
-	if !found {
-		return "<nil-service-name>"
+// sanitizeSpanURLs strips credentials, query string and fragment from the http.url of the span,
+// and query string and fragment from its http.target. The external URL only keeps the hostname
+// and httpPath the path already.
+func sanitizeSpanURLs(span *signoz.Span) {
+	if span.HttpUrl != "" {
+		span.HttpUrl = sanitizeURL(span.HttpUrl)
 	}
-
-	return service.StringVal()
-}
-
-func populateOtherDimensions(attributes pdata.AttributeMap, span *Span) {
-
-	attributes.Range(func(k string, v pdata.AttributeValue) bool {
-		if k == "http.status_code" {
-			if v.IntVal() >= 400 {
-				span.HasError = true
-			}
-			span.HttpCode = strconv.FormatInt(v.IntVal(), 10)
-			span.ResponseStatusCode = span.HttpCode
-		} else if k == "http.url" && span.Kind == 3 {
-			value := v.StringVal()
-			valueUrl, err := url.Parse(value)
-			if err == nil {
-				value = valueUrl.Hostname()
-			}
-			span.ExternalHttpUrl = value
-		} else if k == "http.method" && span.Kind == 3 {
-			span.ExternalHttpMethod = v.StringVal()
-		} else if k == "http.url" && span.Kind != 3 {
-			span.HttpUrl = v.StringVal()
-		} else if k == "http.method" && span.Kind != 3 {
-			span.HttpMethod = v.StringVal()
-		} else if k == "http.route" {
-			span.HttpRoute = v.StringVal()
-		} else if k == "http.host" {
-			span.HttpHost = v.StringVal()
-		} else if k == "messaging.system" {
-			span.MsgSystem = v.StringVal()
-		} else if k == "messaging.operation" {
-			span.MsgOperation = v.StringVal()
-		} else if k == "component" {
-			span.Component = v.StringVal()
-		} else if k == "db.system" {
-			span.DBSystem = v.StringVal()
-		} else if k == "db.name" {
-			span.DBName = v.StringVal()
-		} else if k == "db.operation" {
-			span.DBOperation = v.StringVal()
-		} else if k == "peer.service" {
-			span.PeerService = v.StringVal()
-		} else if k == "rpc.grpc.status_code" {
-			// Handle both string/int status code in GRPC spans.
-			statusString, err := strconv.Atoi(v.StringVal())
-			statusInt := v.IntVal()
-			if err == nil && statusString != 0 {
-				statusInt = int64(statusString)
-			}
-			if statusInt >= 2 {
-				span.HasError = true
-			}
-			span.GRPCCode = strconv.FormatInt(statusInt, 10)
-			span.ResponseStatusCode = span.GRPCCode
-		} else if k == "rpc.method" {
-			span.RPCMethod = v.StringVal()
-			system, found := attributes.Get("rpc.system")
-			if found && system.StringVal() == "grpc" {
-				span.GRPCMethod = v.StringVal()
-			}
-		} else if k == "rpc.service" {
-			span.RPCService = v.StringVal()
-		} else if k == "rpc.system" {
-			span.RPCSystem = v.StringVal()
-		} else if k == "rpc.jsonrpc.error_code" {
-			span.ResponseStatusCode = v.StringVal()
-		}
-		return true
-
-	})
-}
-
-func populateEvents(events pdata.SpanEventSlice, span *Span) {
-	for i := 0; i < events.Len(); i++ {
-		event := Event{}
-		event.Name = events.At(i).Name()
-		event.TimeUnixNano = uint64(events.At(i).Timestamp())
-		event.AttributeMap = map[string]string{}
-		event.IsError = false
-		events.At(i).Attributes().Range(func(k string, v pdata.AttributeValue) bool {
-			if v.Type().String() == "INT" {
-				event.AttributeMap[k] = strconv.FormatInt(v.IntVal(), 10)
-			} else {
-				event.AttributeMap[k] = v.StringVal()
-			}
-			return true
-		})
-		if event.Name == "exception" {
-			event.IsError = true
-			span.ErrorEvent = event
-			uuidWithHyphen := uuid.New()
-			uuid := strings.Replace(uuidWithHyphen.String(), "-", "", -1)
-			span.ErrorID = uuid
-			hmd5 := md5.Sum([]byte(span.ServiceName + span.ErrorEvent.AttributeMap["exception.type"] + span.ErrorEvent.AttributeMap["exception.message"]))
-			span.ErrorGroupID = fmt.Sprintf("%x", hmd5)
-		}
-		stringEvent, _ := json.Marshal(event)
-		span.Events = append(span.Events, string(stringEvent))
+	// The tag map is shared with the trace model.
+	if value, ok := span.TagMap["http.url"]; ok {
+		span.TagMap["http.url"] = sanitizeURL(value)
+	}
+	if value, ok := span.TagMap["http.target"]; ok {
+		span.TagMap["http.target"] = sanitizeTarget(value)
 	}
 }
 
-func populateTraceModel(span *Span) {
-	span.TraceModel.Events = span.Events
-	span.TraceModel.HasError = span.HasError
-}
-
-func newStructuredSpan(otelSpan pdata.Span, ServiceName string, resource pdata.Resource) *Span {
-
-	durationNano := uint64(otelSpan.EndTimestamp() - otelSpan.StartTimestamp())
-
-	attributes := otelSpan.Attributes()
-	resourceAttributes := resource.Attributes()
-	tagMap := map[string]string{}
-
-	attributes.Range(func(k string, v pdata.AttributeValue) bool {
-		v.StringVal()
-		if v.Type().String() == "INT" {
-			tagMap[k] = strconv.FormatInt(v.IntVal(), 10)
-		} else if v.StringVal() != "" {
-			tagMap[k] = v.StringVal()
-		}
-		return true
-
-	})
-
-	resourceAttributes.Range(func(k string, v pdata.AttributeValue) bool {
-		v.StringVal()
-		if v.Type().String() == "INT" {
-			tagMap[k] = strconv.FormatInt(v.IntVal(), 10)
-		} else if v.StringVal() != "" {
-			tagMap[k] = v.StringVal()
-		}
-		return true
-
-	})
-
-	references, _ := makeJaegerProtoReferences(otelSpan.Links(), otelSpan.ParentSpanID(), otelSpan.TraceID())
-
-	var span *Span = &Span{
-		TraceId:           otelSpan.TraceID().HexString(),
-		SpanId:            otelSpan.SpanID().HexString(),
-		ParentSpanId:      otelSpan.ParentSpanID().HexString(),
-		Name:              otelSpan.Name(),
-		StartTimeUnixNano: uint64(otelSpan.StartTimestamp()),
-		DurationNano:      durationNano,
-		ServiceName:       ServiceName,
-		Kind:              int8(otelSpan.Kind()),
-		StatusCode:        int16(otelSpan.Status().Code()),
-		TagMap:            tagMap,
-		HasError:          false,
-		TraceModel: TraceModel{
-			TraceId:           otelSpan.TraceID().HexString(),
-			SpanId:            otelSpan.SpanID().HexString(),
-			Name:              otelSpan.Name(),
-			DurationNano:      durationNano,
-			StartTimeUnixNano: uint64(otelSpan.StartTimestamp()),
-			ServiceName:       ServiceName,
-			Kind:              int8(otelSpan.Kind()),
-			References:        references,
-			TagMap:            tagMap,
-			HasError:          false,
-		},
+// sanitizeTarget strips the query string and fragment from a request target.
+func sanitizeTarget(value string) string {
+	if i := strings.IndexAny(value, "?#"); i >= 0 {
+		return value[:i]
 	}
+	return value
+}
 
-	if span.StatusCode == 2 {
-		span.HasError = true
+func sanitizeURL(value string) string {
+	u, err := url.Parse(value)
+	if err != nil {
+		// Don't store what can't be sanitized.
+		return ""
 	}
-	populateOtherDimensions(attributes, span)
-	populateEvents(otelSpan.Events(), span)
-	populateTraceModel(span)
-
-	return span
+	u.RawQuery = ""
+	u.ForceQuery = false
+	u.Fragment = ""
+	u.RawFragment = ""
+	u.User = nil
+	return u.String()
 }
 
 // traceDataPusher implements OTEL exporterhelper.traceDataPusher
 func (s *storage) pushTraceData(ctx context.Context, td pdata.Traces) error {
+	received := time.Now()
+	var enqueueLatency time.Duration
 
 	rss := td.ResourceSpans()
 	for i := 0; i < rss.Len(); i++ {
 		// fmt.Printf("ResourceSpans #%d\n", i)
 		rs := rss.At(i)
 
-		serviceName := ServiceNameForResource(rs.Resource())
+		serviceName := signoz.ServiceNameForResource(rs.Resource())
 
 		ilss := rs.InstrumentationLibrarySpans()
 		for j := 0; j < ilss.Len(); j++ {
@@ -307,15 +187,45 @@ func (s *storage) pushTraceData(ctx context.Context, td pdata.Traces) error {
 
 			for k := 0; k < spans.Len(); k++ {
 				span := spans.At(k)
+				if s.dedup != nil && s.dedup.seen(span.TraceID(), span.SpanID()) {
+					continue
+				}
 				// traceID := hex.EncodeToString(span.TraceID())
-				structuredSpan := newStructuredSpan(span, serviceName, rs.Resource())
+				structuredSpan := signoz.NewStructuredSpan(span, serviceName, rs.Resource(), signoz.Options{
+					ErrorMatcher:               s.errorMatcher,
+					SerializeComplexAttributes: s.serializeComplexAttributes,
+				})
+				if s.clockSkewThreshold > 0 {
+					correctClockSkew(structuredSpan, received, s.clockSkewThreshold)
+				}
+				if s.lateSpans != nil && !s.lateSpans.apply(structuredSpan) {
+					continue
+				}
+				structuredSpan.CollectorHostname = s.collectorHostname
+				structuredSpan.CollectorInstanceID = s.collectorInstanceID
+				if s.omitIndexEvents {
+					// The trace model keeps its own reference to the events.
+					structuredSpan.Events = nil
+				}
+				if s.sanitizeURLs {
+					sanitizeSpanURLs(structuredSpan)
+				}
+				if s.catalog != nil {
+					s.catalog.enrich(structuredSpan)
+				}
+				if s.errorLimiter != nil {
+					s.errorLimiter.limitErrors(structuredSpan)
+				}
+				enqueueStart := time.Now()
 				err := s.Writer.WriteSpan(structuredSpan)
+				enqueueLatency += time.Since(enqueueStart)
 				if err != nil {
-					zap.S().Error("Error in writing spans to clickhouse: ", err)
+					s.writeErrors.log("Could not queue a span for writing", "", []*signoz.Span{structuredSpan}, err)
 				}
 			}
 		}
 	}
 
+	recordPushLatencies(ctx, time.Since(received), enqueueLatency)
 	return nil
 }
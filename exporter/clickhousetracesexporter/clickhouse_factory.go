@@ -26,6 +26,8 @@ import (
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signoz"
 )
 
 // Factory implements storage.Factory for Clickhouse backend.
@@ -40,10 +42,10 @@ type Factory struct {
 
 // Writer writes spans to storage.
 type Writer interface {
-	WriteSpan(span *Span) error
+	WriteSpan(span *signoz.Span) error
 }
 
-type writerMaker func(logger *zap.Logger, db clickhouse.Conn, traceDatabase string, spansTable string, indexTable string, errorTable string, encoding Encoding, delay time.Duration, size int) (Writer, error)
+type writerMaker func(logger *zap.Logger, db clickhouse.Conn, traceDatabase string, spansTable string, indexTable string, errorTable string, eventsTable string, linksTable string, exceptionLogsTable string, encoding Encoding, timeSource TimeColumnSource, delay time.Duration, size int) (Writer, error)
 
 // NewFactory creates a new Factory.
 func ClickHouseNewFactory(migrations string, datasource string) *Factory {
@@ -52,8 +54,8 @@ func ClickHouseNewFactory(migrations string, datasource string) *Factory {
 		// makeReader: func(db *clickhouse.Conn, operationsTable, indexTable, spansTable string) (spanstore.Reader, error) {
 		// 	return store.NewTraceReader(db, operationsTable, indexTable, spansTable), nil
 		// },
-		makeWriter: func(logger *zap.Logger, db clickhouse.Conn, traceDatabase string, spansTable string, indexTable string, errorTable string, encoding Encoding, delay time.Duration, size int) (Writer, error) {
-			return NewSpanWriter(logger, db, traceDatabase, spansTable, indexTable, errorTable, encoding, delay, size), nil
+		makeWriter: func(logger *zap.Logger, db clickhouse.Conn, traceDatabase string, spansTable string, indexTable string, errorTable string, eventsTable string, linksTable string, exceptionLogsTable string, encoding Encoding, timeSource TimeColumnSource, delay time.Duration, size int) (Writer, error) {
+			return NewSpanWriter(logger, db, traceDatabase, spansTable, indexTable, errorTable, eventsTable, linksTable, exceptionLogsTable, encoding, timeSource, delay, size), nil
 		},
 	}
 }
@@ -144,7 +146,7 @@ func (f *Factory) InitFromViper(v *viper.Viper) {
 // CreateSpanWriter implements storage.Factory
 func (f *Factory) CreateSpanWriter() (Writer, error) {
 	cfg := f.Options.getPrimary()
-	return f.makeWriter(f.logger, f.db, cfg.TraceDatabase, cfg.SpansTable, cfg.IndexTable, cfg.ErrorTable, cfg.Encoding, cfg.WriteBatchDelay, cfg.WriteBatchSize)
+	return f.makeWriter(f.logger, f.db, cfg.TraceDatabase, cfg.SpansTable, cfg.IndexTable, cfg.ErrorTable, cfg.EventsTable, cfg.LinksTable, cfg.ExceptionLogsTable, cfg.Encoding, cfg.TimeColumnSource, cfg.WriteBatchDelay, cfg.WriteBatchSize)
 }
 
 // CreateArchiveSpanWriter implements storage.ArchiveFactory
@@ -153,7 +155,7 @@ func (f *Factory) CreateArchiveSpanWriter() (Writer, error) {
 		return nil, nil
 	}
 	cfg := f.Options.others[archiveNamespace]
-	return f.makeWriter(f.logger, f.archive, "", cfg.TraceDatabase, cfg.SpansTable, cfg.ErrorTable, cfg.Encoding, cfg.WriteBatchDelay, cfg.WriteBatchSize)
+	return f.makeWriter(f.logger, f.archive, "", cfg.TraceDatabase, cfg.SpansTable, cfg.ErrorTable, cfg.EventsTable, cfg.LinksTable, cfg.ExceptionLogsTable, cfg.Encoding, cfg.TimeColumnSource, cfg.WriteBatchDelay, cfg.WriteBatchSize)
 }
 
 // Close Implements io.Closer and closes the underlying storage
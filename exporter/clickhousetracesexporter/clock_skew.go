@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhousetracesexporter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signoz"
+)
+
+// ClockSkewSettings corrects the time of spans from clients with skewed clocks, so they
+// don't pollute time partitioned data.
+type ClockSkewSettings struct {
+	// Threshold is how far the end time of spans may be from the time the exporter
+	// receives them before their time is corrected. Zero disables the correction.
+	Threshold time.Duration `mapstructure:"threshold"`
+}
+
+func (s ClockSkewSettings) validate() error {
+	if s.Threshold < 0 {
+		return fmt.Errorf("clock_skew.threshold must not be negative. configured value %v", s.Threshold)
+	}
+	return nil
+}
+
+// correctClockSkew moves span to end at received when its end time is more than
+// threshold away from it. The original start time is kept in OriginalStartTimeUnixNano.
+func correctClockSkew(span *signoz.Span, received time.Time, threshold time.Duration) {
+	end := time.Unix(0, int64(span.StartTimeUnixNano+span.DurationNano))
+	if end.After(received.Add(-threshold)) && end.Before(received.Add(threshold)) {
+		return
+	}
+	span.OriginalStartTimeUnixNano = span.StartTimeUnixNano
+	span.StartTimeUnixNano = uint64(received.UnixNano()) - span.DurationNano
+}
@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhousetracesexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signoz"
+)
+
+func TestCorrectClockSkew(t *testing.T) {
+	received := time.Unix(10000, 0)
+	duration := uint64(time.Second)
+	tests := []struct {
+		name         string
+		start        time.Time
+		wantStart    time.Time
+		wantOriginal uint64
+	}{
+		{name: "within threshold", start: received.Add(-time.Minute), wantStart: received.Add(-time.Minute)},
+		{name: "in the past", start: received.Add(-time.Hour), wantStart: received.Add(-time.Second), wantOriginal: uint64(received.Add(-time.Hour).UnixNano())},
+		{name: "in the future", start: received.Add(time.Hour), wantStart: received.Add(-time.Second), wantOriginal: uint64(received.Add(time.Hour).UnixNano())},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			span := &signoz.Span{StartTimeUnixNano: uint64(tt.start.UnixNano()), DurationNano: duration}
+			correctClockSkew(span, received, 5*time.Minute)
+			assert.Equal(t, uint64(tt.wantStart.UnixNano()), span.StartTimeUnixNano)
+			assert.Equal(t, tt.wantOriginal, span.OriginalStartTimeUnixNano)
+		})
+	}
+}
+
+func TestClockSkewSettingsValidate(t *testing.T) {
+	assert.NoError(t, ClockSkewSettings{Threshold: time.Hour}.validate())
+	assert.EqualError(t, ClockSkewSettings{Threshold: -time.Hour}.validate(), "clock_skew.threshold must not be negative. configured value -1h0m0s")
+}
@@ -15,7 +15,11 @@
 package clickhousetracesexporter
 
 import (
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signoz"
 )
 
 // Config defines configuration for logging exporter.
@@ -25,11 +29,61 @@ type Config struct {
 	Options    `mapstructure:",squash"`
 	Datasource string `mapstructure:"datasource"`
 	Migrations string `mapstructure:"migrations"`
+	// HasError configures which spans are flagged with hasError.
+	HasError signoz.HasErrorSettings `mapstructure:"has_error"`
+	// SanitizeURLs strips credentials, query string and fragment from http.url before
+	// it is stored, as they often carry tokens or personal data.
+	SanitizeURLs bool `mapstructure:"sanitize_urls"`
+	// ServiceCatalog attaches the team, owner and tier of the service to spans.
+	ServiceCatalog ServiceCatalogSettings `mapstructure:"service_catalog"`
+	// SerializeComplexAttributes stores array and map attribute values in tagMap as
+	// JSON strings instead of dropping them.
+	SerializeComplexAttributes bool `mapstructure:"serialize_complex_attributes"`
+	// OmitIndexEvents leaves the events out of the index rows. They are still stored
+	// with the trace model, which is where the query service reads them from.
+	OmitIndexEvents bool `mapstructure:"omit_index_events"`
+	// WriteSpanEvents writes one row per span event to signoz_span_events.
+	WriteSpanEvents bool `mapstructure:"write_span_events"`
+	// WriteSpanLinks writes one row per span link to signoz_span_links.
+	WriteSpanLinks bool `mapstructure:"write_span_links"`
+	// WriteExceptionLogs writes one ERROR log row per exception event to signoz_exception_logs.
+	WriteExceptionLogs bool `mapstructure:"write_exception_logs"`
+	// ErrorRateLimit caps the rows written to the error index per error group.
+	ErrorRateLimit ErrorRateLimitSettings `mapstructure:"error_rate_limit"`
+	// TimeColumnSource selects whether the timestamp column of the index and model
+	// rows holds the start (default) or the end time of spans.
+	TimeColumnSource TimeColumnSource `mapstructure:"time_column_source"`
+	// ClockSkew corrects the time of spans far from the time they are received.
+	ClockSkew ClockSkewSettings `mapstructure:"clock_skew"`
+	// LateSpans bounds how far in the past or future spans may start.
+	LateSpans LateSpanSettings `mapstructure:"late_spans"`
+	// Dedup skips spans exported again within a window.
+	Dedup DedupSettings `mapstructure:"dedup"`
 }
 
 var _ config.Exporter = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
 func (cfg *Config) Validate() error {
-	return nil
+	if _, err := signoz.NewErrorMatcher(cfg.HasError); err != nil {
+		return err
+	}
+	switch cfg.TimeColumnSource {
+	case "", TimeColumnSourceStart, TimeColumnSourceEnd:
+	default:
+		return fmt.Errorf("time_column_source must be %q or %q. configured value %q", TimeColumnSourceStart, TimeColumnSourceEnd, cfg.TimeColumnSource)
+	}
+	if err := cfg.Dedup.validate(); err != nil {
+		return err
+	}
+	if err := cfg.ClockSkew.validate(); err != nil {
+		return err
+	}
+	if err := cfg.LateSpans.validate(); err != nil {
+		return err
+	}
+	if err := cfg.ServiceCatalog.validate(); err != nil {
+		return err
+	}
+	return cfg.ErrorRateLimit.validate()
 }
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhousetracesexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidateTimeColumnSource(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Equal(t, TimeColumnSourceStart, cfg.TimeColumnSource)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.TimeColumnSource = TimeColumnSourceEnd
+	assert.NoError(t, cfg.Validate())
+
+	cfg.TimeColumnSource = "finish"
+	assert.EqualError(t, cfg.Validate(), `time_column_source must be "start" or "end". configured value "finish"`)
+}
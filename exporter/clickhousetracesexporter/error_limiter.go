@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhousetracesexporter
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signoz"
+)
+
+// ErrorRateLimitSettings caps the rows written to the error index per error group,
+// so an exception storm from a single bug doesn't flood the table.
+type ErrorRateLimitSettings struct {
+	// MaxPerGroup is the number of errors of a group written per window. Zero disables the limit.
+	MaxPerGroup int `mapstructure:"max_per_group"`
+	// Window is the period MaxPerGroup applies to (default 1m).
+	Window time.Duration `mapstructure:"window"`
+}
+
+func (s ErrorRateLimitSettings) validate() error {
+	if s.MaxPerGroup < 0 {
+		return fmt.Errorf("error_rate_limit.max_per_group must not be negative. configured value %v", s.MaxPerGroup)
+	}
+	if s.MaxPerGroup > 0 && s.Window <= 0 {
+		return fmt.Errorf("error_rate_limit.window must be positive. configured value %v", s.Window)
+	}
+	return nil
+}
+
+// errorLimiter counts the errors of each group in fixed windows.
+type errorLimiter struct {
+	maxPerGroup int
+	window      time.Duration
+	now         func() time.Time
+	logger      *zap.Logger
+
+	mu        sync.Mutex
+	groups    map[string]*errorGroupWindow
+	lastPrune time.Time
+}
+
+type errorGroupWindow struct {
+	start   time.Time
+	written int
+	// dropped is the number of errors not written since the last written one.
+	dropped uint64
+}
+
+func newErrorLimiter(settings ErrorRateLimitSettings, logger *zap.Logger) *errorLimiter {
+	return &errorLimiter{
+		maxPerGroup: settings.MaxPerGroup,
+		window:      settings.Window,
+		now:         time.Now,
+		logger:      logger,
+		groups:      make(map[string]*errorGroupWindow),
+	}
+}
+
+// allow reports whether an error of group may be written. When it may, it also
+// returns the number of errors of the group dropped before it.
+func (l *errorLimiter) allow(group string) (bool, uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	w, ok := l.groups[group]
+	if !ok {
+		w = &errorGroupWindow{start: now}
+		l.groups[group] = w
+	} else if now.Sub(w.start) >= l.window {
+		w.start = now
+		w.written = 0
+	}
+	// Prune after starting the window of group, so its dropped count goes to its next row.
+	l.prune(now)
+
+	if w.written >= l.maxPerGroup {
+		w.dropped++
+		return false, 0
+	}
+	w.written++
+	dropped := w.dropped
+	w.dropped = 0
+	return true, dropped
+}
+
+// prune forgets the groups whose window expired, at most once per window, so groups
+// that stop erroring don't pile up. The count of errors dropped since the last row
+// written for a group can't be stored with a row anymore, it is logged instead.
+func (l *errorLimiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < l.window {
+		return
+	}
+	l.lastPrune = now
+	for group, w := range l.groups {
+		if now.Sub(w.start) < l.window {
+			continue
+		}
+		if w.dropped > 0 {
+			l.logger.Info("Error rows dropped by the rate limit",
+				zap.String("groupID", group), zap.Uint64("count", w.dropped))
+		}
+		delete(l.groups, group)
+	}
+}
+
+// limitErrors keeps the error event of span out of the error index when its group exceeded the limit.
+func (l *errorLimiter) limitErrors(span *signoz.Span) {
+	if span.ErrorEvent.Name == "" {
+		return
+	}
+	ok, dropped := l.allow(span.ErrorGroupID)
+	if !ok {
+		span.ErrorEvent = signoz.Event{}
+		return
+	}
+	span.ErrorDropped = dropped
+}
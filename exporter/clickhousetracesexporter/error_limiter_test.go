@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhousetracesexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signoz"
+)
+
+func TestErrorLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := newErrorLimiter(ErrorRateLimitSettings{MaxPerGroup: 2, Window: time.Minute}, zap.NewNop())
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		ok, dropped := l.allow("a")
+		assert.True(t, ok)
+		assert.Zero(t, dropped)
+	}
+	for i := 0; i < 3; i++ {
+		ok, _ := l.allow("a")
+		assert.False(t, ok)
+	}
+	// Other groups have limits of their own.
+	ok, _ := l.allow("b")
+	assert.True(t, ok)
+
+	now = now.Add(time.Minute)
+	ok, dropped := l.allow("a")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(3), dropped)
+	ok, dropped = l.allow("a")
+	assert.True(t, ok)
+	assert.Zero(t, dropped)
+
+	// b expired without drops and was pruned.
+	assert.NotContains(t, l.groups, "b")
+}
+
+func TestErrorLimiterPrunesGroupsWithDrops(t *testing.T) {
+	now := time.Unix(0, 0)
+	core, logs := observer.New(zapcore.InfoLevel)
+	l := newErrorLimiter(ErrorRateLimitSettings{MaxPerGroup: 1, Window: time.Minute}, zap.New(core))
+	l.now = func() time.Time { return now }
+
+	l.allow("a")
+	for i := 0; i < 2; i++ {
+		ok, _ := l.allow("a")
+		assert.False(t, ok)
+	}
+
+	// The group never errors again, it is pruned along with its pending drops.
+	now = now.Add(time.Minute)
+	l.allow("b")
+	assert.NotContains(t, l.groups, "a")
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, map[string]interface{}{"groupID": "a", "count": uint64(2)}, logs.All()[0].ContextMap())
+}
+
+func TestErrorLimiterLimitErrors(t *testing.T) {
+	l := newErrorLimiter(ErrorRateLimitSettings{MaxPerGroup: 1, Window: time.Minute}, zap.NewNop())
+
+	span := &signoz.Span{ErrorGroupID: "a", ErrorEvent: signoz.Event{Name: "exception"}}
+	l.limitErrors(span)
+	assert.Equal(t, "exception", span.ErrorEvent.Name)
+
+	span = &signoz.Span{ErrorGroupID: "a", ErrorEvent: signoz.Event{Name: "exception"}}
+	l.limitErrors(span)
+	assert.Empty(t, span.ErrorEvent.Name)
+
+	// Spans without errors are left alone.
+	span = &signoz.Span{ErrorGroupID: "a"}
+	l.limitErrors(span)
+	assert.Zero(t, span.ErrorDropped)
+}
+
+func TestErrorRateLimitSettingsValidate(t *testing.T) {
+	assert.NoError(t, ErrorRateLimitSettings{}.validate())
+	assert.NoError(t, ErrorRateLimitSettings{MaxPerGroup: 10, Window: time.Minute}.validate())
+	assert.Error(t, ErrorRateLimitSettings{MaxPerGroup: -1}.validate())
+	assert.Error(t, ErrorRateLimitSettings{MaxPerGroup: 10}.validate())
+}
@@ -16,8 +16,9 @@ package clickhousetracesexporter
 
 import (
 	"context"
-	"io"
+	"time"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
@@ -28,6 +29,10 @@ const (
 	typeStr          = "clickhousetraces"
 	primaryNamespace = "clickhouse"
 	archiveNamespace = "clickhouse-archive"
+
+	defaultServiceCatalogRefreshInterval = 5 * time.Minute
+	defaultErrorRateLimitWindow          = time.Minute
+	defaultDedupMaxEntries               = 100000
 )
 
 func createDefaultConfig() config.Exporter {
@@ -35,11 +40,23 @@ func createDefaultConfig() config.Exporter {
 	return &Config{
 		// Options:          *opts,
 		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		ServiceCatalog: ServiceCatalogSettings{
+			RefreshInterval: defaultServiceCatalogRefreshInterval,
+		},
+		ErrorRateLimit: ErrorRateLimitSettings{
+			Window: defaultErrorRateLimitWindow,
+		},
+		TimeColumnSource: TimeColumnSourceStart,
+		Dedup: DedupSettings{
+			MaxEntries: defaultDedupMaxEntries,
+		},
 	}
 }
 
 // NewFactory creates a factory for Logging exporter
 func NewFactory() component.ExporterFactory {
+	view.Register(MetricViews()...)
+
 	return exporterhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
@@ -62,10 +79,6 @@ func createTracesExporter(
 		cfg,
 		params,
 		oce.pushTraceData,
-		exporterhelper.WithShutdown(func(context.Context) error {
-			if closer, ok := oce.Writer.(io.Closer); ok {
-				return closer.Close()
-			}
-			return nil
-		}))
+		exporterhelper.WithStart(oce.start),
+		exporterhelper.WithShutdown(oce.shutdown))
 }
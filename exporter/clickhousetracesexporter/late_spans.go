@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhousetracesexporter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signoz"
+)
+
+// LateSpanAction is what happens to spans starting too far in the past or future.
+type LateSpanAction string
+
+const (
+	// LateSpanActionFlag stores late spans as they are, with lateArrival set.
+	LateSpanActionFlag LateSpanAction = "flag"
+	// LateSpanActionClamp moves the start time of late spans to the closest time
+	// allowed, and sets lateArrival.
+	LateSpanActionClamp LateSpanAction = "clamp"
+	// LateSpanActionDrop doesn't store late spans.
+	LateSpanActionDrop LateSpanAction = "drop"
+)
+
+// LateSpanSettings bounds how far the start time of spans may be from the time they
+// are exported, so clock-skewed SDKs don't create rows outside the retention window.
+type LateSpanSettings struct {
+	// MaxPast is how long before the export time spans may start. Zero disables the bound.
+	MaxPast time.Duration `mapstructure:"max_past"`
+	// MaxFuture is how long after the export time spans may start. Zero disables the bound.
+	MaxFuture time.Duration `mapstructure:"max_future"`
+	// Action is applied to spans outside the bounds: flag (default), clamp or drop.
+	Action LateSpanAction `mapstructure:"action"`
+}
+
+func (s LateSpanSettings) enabled() bool {
+	return s.MaxPast > 0 || s.MaxFuture > 0
+}
+
+func (s LateSpanSettings) validate() error {
+	if s.MaxPast < 0 {
+		return fmt.Errorf("late_spans.max_past must not be negative. configured value %v", s.MaxPast)
+	}
+	if s.MaxFuture < 0 {
+		return fmt.Errorf("late_spans.max_future must not be negative. configured value %v", s.MaxFuture)
+	}
+	switch s.Action {
+	case "", LateSpanActionFlag, LateSpanActionClamp, LateSpanActionDrop:
+		return nil
+	}
+	return fmt.Errorf("late_spans.action must be %q, %q or %q. configured value %q", LateSpanActionFlag, LateSpanActionClamp, LateSpanActionDrop, s.Action)
+}
+
+// lateSpanPolicy applies LateSpanSettings to spans.
+type lateSpanPolicy struct {
+	maxPast   time.Duration
+	maxFuture time.Duration
+	action    LateSpanAction
+	now       func() time.Time
+}
+
+func newLateSpanPolicy(settings LateSpanSettings) *lateSpanPolicy {
+	action := settings.Action
+	if action == "" {
+		action = LateSpanActionFlag
+	}
+	return &lateSpanPolicy{
+		maxPast:   settings.MaxPast,
+		maxFuture: settings.MaxFuture,
+		action:    action,
+		now:       time.Now,
+	}
+}
+
+// apply flags or clamps span when it starts outside the bounds. It returns false
+// when span is late and must be dropped.
+func (p *lateSpanPolicy) apply(span *signoz.Span) bool {
+	now := p.now()
+	start := time.Unix(0, int64(span.StartTimeUnixNano))
+
+	var bound time.Time
+	switch {
+	case p.maxPast > 0 && start.Before(now.Add(-p.maxPast)):
+		bound = now.Add(-p.maxPast)
+	case p.maxFuture > 0 && start.After(now.Add(p.maxFuture)):
+		bound = now.Add(p.maxFuture)
+	default:
+		return true
+	}
+
+	switch p.action {
+	case LateSpanActionDrop:
+		return false
+	case LateSpanActionClamp:
+		span.StartTimeUnixNano = uint64(bound.UnixNano())
+	}
+	span.LateArrival = true
+	return true
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhousetracesexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signoz"
+)
+
+func TestLateSpanPolicy(t *testing.T) {
+	now := time.Unix(10000, 0)
+	settings := LateSpanSettings{MaxPast: time.Hour, MaxFuture: time.Minute}
+	tests := []struct {
+		name      string
+		action    LateSpanAction
+		start     time.Time
+		keep      bool
+		late      bool
+		wantStart time.Time
+	}{
+		{name: "in bounds", action: LateSpanActionDrop, start: now.Add(-time.Minute), keep: true, wantStart: now.Add(-time.Minute)},
+		{name: "flag past", start: now.Add(-2 * time.Hour), keep: true, late: true, wantStart: now.Add(-2 * time.Hour)},
+		{name: "clamp past", action: LateSpanActionClamp, start: now.Add(-2 * time.Hour), keep: true, late: true, wantStart: now.Add(-time.Hour)},
+		{name: "clamp future", action: LateSpanActionClamp, start: now.Add(time.Hour), keep: true, late: true, wantStart: now.Add(time.Minute)},
+		{name: "drop future", action: LateSpanActionDrop, start: now.Add(time.Hour)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings.Action = tt.action
+			p := newLateSpanPolicy(settings)
+			p.now = func() time.Time { return now }
+			span := &signoz.Span{StartTimeUnixNano: uint64(tt.start.UnixNano())}
+
+			assert.Equal(t, tt.keep, p.apply(span))
+			if tt.keep {
+				assert.Equal(t, tt.late, span.LateArrival)
+				assert.Equal(t, uint64(tt.wantStart.UnixNano()), span.StartTimeUnixNano)
+			}
+		})
+	}
+}
+
+func TestLateSpanSettingsValidate(t *testing.T) {
+	assert.NoError(t, LateSpanSettings{MaxPast: time.Hour, Action: LateSpanActionClamp}.validate())
+	assert.EqualError(t, LateSpanSettings{MaxFuture: -time.Second}.validate(), "late_spans.max_future must not be negative. configured value -1s")
+	assert.EqualError(t, LateSpanSettings{Action: "skip"}.validate(), `late_spans.action must be "flag", "clamp" or "drop". configured value "skip"`)
+}
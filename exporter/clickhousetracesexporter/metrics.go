@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhousetracesexporter
+
+import (
+	"context"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	mConversionLatency = stats.Float64("clickhousetraces_conversion_latency", "Time spent converting a batch of traces to rows", stats.UnitMilliseconds)
+	mEnqueueLatency    = stats.Float64("clickhousetraces_enqueue_latency", "Time a batch of traces waited for room in the write queue", stats.UnitMilliseconds)
+	mWriteLatency      = stats.Float64("clickhousetraces_write_latency", "Time spent writing a batch of rows to a table", stats.UnitMilliseconds)
+
+	tableKey   = tag.MustNewKey("table")
+	successKey = tag.MustNewKey("success")
+
+	latencyBuckets = []float64{0, 1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 2000, 5000, 10000}
+)
+
+// MetricViews returns the views of the exporter's self metrics. Comparing the conversion
+// and enqueue latencies with the write latency tells collector CPU saturation apart
+// from a slow ClickHouse.
+func MetricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        mConversionLatency.Name(),
+			Measure:     mConversionLatency,
+			Description: mConversionLatency.Description(),
+			Aggregation: view.Distribution(latencyBuckets...),
+		},
+		{
+			Name:        mEnqueueLatency.Name(),
+			Measure:     mEnqueueLatency,
+			Description: mEnqueueLatency.Description(),
+			Aggregation: view.Distribution(latencyBuckets...),
+		},
+		{
+			Name:        mWriteLatency.Name(),
+			Measure:     mWriteLatency,
+			Description: mWriteLatency.Description(),
+			TagKeys:     []tag.Key{tableKey, successKey},
+			Aggregation: view.Distribution(latencyBuckets...),
+		},
+	}
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// recordPushLatencies records how long pushing a batch of traces took, split into
+// the time waiting for the write queue and the rest spent converting spans.
+func recordPushLatencies(ctx context.Context, total time.Duration, enqueue time.Duration) {
+	stats.Record(ctx, mConversionLatency.M(milliseconds(total-enqueue)), mEnqueueLatency.M(milliseconds(enqueue)))
+}
+
+func recordWriteLatency(table string, d time.Duration, err error) {
+	success := "true"
+	if err != nil {
+		success = "false"
+	}
+	ctx, _ := tag.New(context.Background(), tag.Upsert(tableKey, table), tag.Upsert(successKey, success))
+	stats.Record(ctx, mWriteLatency.M(milliseconds(d)))
+}
@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhousetracesexporter
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+func TestMetricViews(t *testing.T) {
+	expectedViewNames := []string{
+		"clickhousetraces_conversion_latency",
+		"clickhousetraces_enqueue_latency",
+		"clickhousetraces_write_latency",
+	}
+
+	views := MetricViews()
+	require.Len(t, views, len(expectedViewNames))
+	for i, viewName := range expectedViewNames {
+		assert.Equal(t, viewName, views[i].Name)
+	}
+}
+
+func TestRecordWriteLatency(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	recordWriteLatency("signoz_index_v2", 20*time.Millisecond, nil)
+	recordWriteLatency("signoz_index_v2", 40*time.Millisecond, errors.New("boom"))
+
+	rows, err := view.RetrieveData("clickhousetraces_write_latency")
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	for _, row := range rows {
+		assert.Contains(t, row.Tags, tag.Tag{Key: tableKey, Value: "signoz_index_v2"})
+		assert.Equal(t, int64(1), row.Data.(*view.DistributionData).Count)
+	}
+}
@@ -0,0 +1 @@
+ALTER TABLE signoz_traces.signoz_index_v2 DROP COLUMN IF EXISTS httpPath
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE signoz_traces.signoz_index_v2 ADD COLUMN IF NOT EXISTS httpPath String CODEC(ZSTD(1))
\ No newline at end of file
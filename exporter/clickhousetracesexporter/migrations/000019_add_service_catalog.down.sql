@@ -0,0 +1,4 @@
+ALTER TABLE signoz_traces.signoz_index_v2
+    DROP COLUMN IF EXISTS `team`,
+    DROP COLUMN IF EXISTS `owner`,
+    DROP COLUMN IF EXISTS `tier`;
\ No newline at end of file
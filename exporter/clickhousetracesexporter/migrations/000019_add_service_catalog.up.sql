@@ -0,0 +1,4 @@
+ALTER TABLE signoz_traces.signoz_index_v2
+    ADD COLUMN IF NOT EXISTS `team` LowCardinality(String) CODEC(ZSTD(1)),
+    ADD COLUMN IF NOT EXISTS `owner` LowCardinality(String) CODEC(ZSTD(1)),
+    ADD COLUMN IF NOT EXISTS `tier` LowCardinality(String) CODEC(ZSTD(1));
\ No newline at end of file
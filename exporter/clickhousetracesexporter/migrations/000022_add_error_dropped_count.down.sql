@@ -0,0 +1 @@
+ALTER TABLE signoz_traces.signoz_error_index_v2 DROP COLUMN IF EXISTS droppedCount
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE signoz_traces.signoz_error_index_v2 ADD COLUMN IF NOT EXISTS droppedCount UInt64 CODEC(T64, ZSTD(1))
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE signoz_traces.signoz_index_v2 DROP COLUMN IF EXISTS schemaVersion;
+ALTER TABLE signoz_traces.signoz_spans DROP COLUMN IF EXISTS schemaVersion;
+ALTER TABLE signoz_traces.signoz_error_index_v2 DROP COLUMN IF EXISTS schemaVersion;
\ No newline at end of file
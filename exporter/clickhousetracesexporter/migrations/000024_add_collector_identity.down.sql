@@ -0,0 +1,3 @@
+ALTER TABLE signoz_traces.signoz_index_v2 DROP COLUMN IF EXISTS collectorHostname, DROP COLUMN IF EXISTS collectorInstanceId;
+ALTER TABLE signoz_traces.signoz_spans DROP COLUMN IF EXISTS collectorHostname, DROP COLUMN IF EXISTS collectorInstanceId;
+ALTER TABLE signoz_traces.signoz_error_index_v2 DROP COLUMN IF EXISTS collectorHostname, DROP COLUMN IF EXISTS collectorInstanceId;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE signoz_traces.signoz_index_v2 ADD COLUMN IF NOT EXISTS collectorHostname LowCardinality(String) CODEC(ZSTD(1)), ADD COLUMN IF NOT EXISTS collectorInstanceId LowCardinality(String) CODEC(ZSTD(1));
+ALTER TABLE signoz_traces.signoz_spans ADD COLUMN IF NOT EXISTS collectorHostname LowCardinality(String) CODEC(ZSTD(1)), ADD COLUMN IF NOT EXISTS collectorInstanceId LowCardinality(String) CODEC(ZSTD(1));
+ALTER TABLE signoz_traces.signoz_error_index_v2 ADD COLUMN IF NOT EXISTS collectorHostname LowCardinality(String) CODEC(ZSTD(1)), ADD COLUMN IF NOT EXISTS collectorInstanceId LowCardinality(String) CODEC(ZSTD(1));
\ No newline at end of file
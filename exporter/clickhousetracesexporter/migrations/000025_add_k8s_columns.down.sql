@@ -0,0 +1,5 @@
+ALTER TABLE signoz_traces.signoz_index_v2
+    DROP COLUMN IF EXISTS `k8sNamespaceName`,
+    DROP COLUMN IF EXISTS `k8sPodName`,
+    DROP COLUMN IF EXISTS `k8sDeploymentName`,
+    DROP COLUMN IF EXISTS `k8sNodeName`;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE signoz_traces.signoz_index_v2
+    ADD COLUMN IF NOT EXISTS `k8sNamespaceName` LowCardinality(String) CODEC(ZSTD(1)),
+    ADD COLUMN IF NOT EXISTS `k8sPodName` LowCardinality(String) CODEC(ZSTD(1)),
+    ADD COLUMN IF NOT EXISTS `k8sDeploymentName` LowCardinality(String) CODEC(ZSTD(1)),
+    ADD COLUMN IF NOT EXISTS `k8sNodeName` LowCardinality(String) CODEC(ZSTD(1));
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE signoz_traces.signoz_index_v2
+    DROP COLUMN IF EXISTS `cloudProvider`,
+    DROP COLUMN IF EXISTS `cloudRegion`,
+    DROP COLUMN IF EXISTS `cloudAvailabilityZone`,
+    DROP COLUMN IF EXISTS `cloudAccountId`;
\ No newline at end of file
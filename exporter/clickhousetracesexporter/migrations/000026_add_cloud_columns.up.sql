@@ -0,0 +1,5 @@
+ALTER TABLE signoz_traces.signoz_index_v2
+    ADD COLUMN IF NOT EXISTS `cloudProvider` LowCardinality(String) CODEC(ZSTD(1)),
+    ADD COLUMN IF NOT EXISTS `cloudRegion` LowCardinality(String) CODEC(ZSTD(1)),
+    ADD COLUMN IF NOT EXISTS `cloudAvailabilityZone` LowCardinality(String) CODEC(ZSTD(1)),
+    ADD COLUMN IF NOT EXISTS `cloudAccountId` LowCardinality(String) CODEC(ZSTD(1));
\ No newline at end of file
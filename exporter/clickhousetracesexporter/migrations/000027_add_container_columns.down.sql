@@ -0,0 +1,4 @@
+ALTER TABLE signoz_traces.signoz_index_v2
+    DROP COLUMN IF EXISTS `containerId`,
+    DROP COLUMN IF EXISTS `containerImageName`,
+    DROP COLUMN IF EXISTS `containerImageTag`;
\ No newline at end of file
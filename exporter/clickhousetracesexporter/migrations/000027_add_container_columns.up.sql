@@ -0,0 +1,4 @@
+ALTER TABLE signoz_traces.signoz_index_v2
+    ADD COLUMN IF NOT EXISTS `containerId` String CODEC(ZSTD(1)),
+    ADD COLUMN IF NOT EXISTS `containerImageName` LowCardinality(String) CODEC(ZSTD(1)),
+    ADD COLUMN IF NOT EXISTS `containerImageTag` LowCardinality(String) CODEC(ZSTD(1));
\ No newline at end of file
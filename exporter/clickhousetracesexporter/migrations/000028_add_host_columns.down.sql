@@ -0,0 +1,3 @@
+ALTER TABLE signoz_traces.signoz_index_v2
+    DROP COLUMN IF EXISTS `hostName`,
+    DROP COLUMN IF EXISTS `osType`;
\ No newline at end of file
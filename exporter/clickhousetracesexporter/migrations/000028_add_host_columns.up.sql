@@ -0,0 +1,3 @@
+ALTER TABLE signoz_traces.signoz_index_v2
+    ADD COLUMN IF NOT EXISTS `hostName` LowCardinality(String) CODEC(ZSTD(1)),
+    ADD COLUMN IF NOT EXISTS `osType` LowCardinality(String) CODEC(ZSTD(1));
\ No newline at end of file
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS signoz_traces.signoz_span_events (
+  timestamp DateTime64(9) CODEC(DoubleDelta, LZ4),
+  traceID FixedString(32) CODEC(ZSTD(1)),
+  spanID String CODEC(ZSTD(1)),
+  serviceName LowCardinality(String) CODEC(ZSTD(1)),
+  name LowCardinality(String) CODEC(ZSTD(1)),
+  attributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+  schemaVersion UInt16 CODEC(T64, ZSTD(1)),
+  collectorHostname LowCardinality(String) CODEC(ZSTD(1)),
+  collectorInstanceId LowCardinality(String) CODEC(ZSTD(1)),
+  INDEX idx_traceID traceID TYPE bloom_filter GRANULARITY 4,
+  INDEX idx_attributeKeys mapKeys(attributes) TYPE bloom_filter(0.01) GRANULARITY 64
+) ENGINE MergeTree()
+PARTITION BY toDate(timestamp)
+ORDER BY (name, serviceName, timestamp)
\ No newline at end of file
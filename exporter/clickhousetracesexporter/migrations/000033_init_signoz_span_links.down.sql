@@ -0,0 +1 @@
+DROP TABLE IF EXISTS signoz_traces.signoz_span_links
\ No newline at end of file
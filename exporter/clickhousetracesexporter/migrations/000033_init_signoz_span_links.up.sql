@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS signoz_traces.signoz_span_links (
+  timestamp DateTime64(9) CODEC(DoubleDelta, LZ4),
+  traceID FixedString(32) CODEC(ZSTD(1)),
+  spanID String CODEC(ZSTD(1)),
+  serviceName LowCardinality(String) CODEC(ZSTD(1)),
+  linkedTraceID FixedString(32) CODEC(ZSTD(1)),
+  linkedSpanID String CODEC(ZSTD(1)),
+  attributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+  schemaVersion UInt16 CODEC(T64, ZSTD(1)),
+  collectorHostname LowCardinality(String) CODEC(ZSTD(1)),
+  collectorInstanceId LowCardinality(String) CODEC(ZSTD(1)),
+  INDEX idx_traceID traceID TYPE bloom_filter GRANULARITY 4
+) ENGINE MergeTree()
+PARTITION BY toDate(timestamp)
+ORDER BY (linkedTraceID, timestamp)
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS signoz_traces.signoz_exception_logs
\ No newline at end of file
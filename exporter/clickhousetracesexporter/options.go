@@ -26,17 +26,20 @@ import (
 )
 
 const (
-	defaultDatasource        string        = "tcp://127.0.0.1:9000/?database=signoz_traces"
-	defaultTraceDatabase     string        = "signoz_traces"
-	defaultMigrations        string        = "/migrations"
-	defaultOperationsTable   string        = "signoz_operations"
-	defaultIndexTable        string        = "signoz_index_v2"
-	defaultErrorTable        string        = "signoz_error_index_v2"
-	defaultSpansTable        string        = "signoz_spans"
-	defaultArchiveSpansTable string        = "signoz_archive_spans"
-	defaultWriteBatchDelay   time.Duration = 5 * time.Second
-	defaultWriteBatchSize    int           = 10000
-	defaultEncoding          Encoding      = EncodingJSON
+	defaultDatasource         string        = "tcp://127.0.0.1:9000/?database=signoz_traces"
+	defaultTraceDatabase      string        = "signoz_traces"
+	defaultMigrations         string        = "/migrations"
+	defaultOperationsTable    string        = "signoz_operations"
+	defaultIndexTable         string        = "signoz_index_v2"
+	defaultErrorTable         string        = "signoz_error_index_v2"
+	defaultEventsTable        string        = "signoz_span_events"
+	defaultLinksTable         string        = "signoz_span_links"
+	defaultExceptionLogsTable string        = "signoz_exception_logs"
+	defaultSpansTable         string        = "signoz_spans"
+	defaultArchiveSpansTable  string        = "signoz_archive_spans"
+	defaultWriteBatchDelay    time.Duration = 5 * time.Second
+	defaultWriteBatchSize     int           = 10000
+	defaultEncoding           Encoding      = EncodingJSON
 )
 
 const (
@@ -63,10 +66,17 @@ type namespaceConfig struct {
 	IndexTable      string
 	SpansTable      string
 	ErrorTable      string
-	WriteBatchDelay time.Duration
-	WriteBatchSize  int
-	Encoding        Encoding
-	Connector       Connector
+	// EventsTable, LinksTable and ExceptionLogsTable are set from the exporter config
+	// when span events, links and exception logs are written.
+	EventsTable        string
+	LinksTable         string
+	ExceptionLogsTable string
+	WriteBatchDelay    time.Duration
+	WriteBatchSize     int
+	Encoding           Encoding
+	// TimeColumnSource is set from the exporter config, there is no flag for it.
+	TimeColumnSource TimeColumnSource
+	Connector        Connector
 }
 
 // Connecto defines how to connect to the database
@@ -0,0 +1,290 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhousetracesexporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signoz"
+)
+
+// ServiceCatalogSettings configures the lookup attaching ownership to spans by service name.
+// The catalog is a YAML or JSON document mapping service names to their team, owner and tier:
+//
+//	frontend:
+//	  team: web
+//	  owner: jane@example.com
+//	  tier: "1"
+//
+// or a CSV document with a header row naming the service, team, owner and tier columns:
+//
+//	service,team,owner,tier
+//	frontend,web,jane@example.com,1
+//
+// Files ending in .csv and endpoints answering with the text/csv content type are read as CSV.
+type ServiceCatalogSettings struct {
+	// File is the path of the catalog document.
+	File string `mapstructure:"file"`
+	// Endpoint is an HTTP URL serving the catalog document.
+	Endpoint string `mapstructure:"endpoint"`
+	// RefreshInterval is how often the catalog is reloaded. It is loaded once when zero. A
+	// failed first load is retried with backoff until it succeeds either way.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+}
+
+func (s ServiceCatalogSettings) enabled() bool {
+	return s.File != "" || s.Endpoint != ""
+}
+
+func (s ServiceCatalogSettings) validate() error {
+	if s.File != "" && s.Endpoint != "" {
+		return errors.New("service_catalog.file and service_catalog.endpoint are mutually exclusive")
+	}
+	if s.RefreshInterval < 0 {
+		return fmt.Errorf("service_catalog.refresh_interval must not be negative. configured value %v", s.RefreshInterval)
+	}
+	return nil
+}
+
+type serviceInfo struct {
+	Team  string `yaml:"team"`
+	Owner string `yaml:"owner"`
+	Tier  string `yaml:"tier"`
+}
+
+// serviceCatalog holds the last successfully loaded catalog.
+type serviceCatalog struct {
+	settings ServiceCatalogSettings
+	logger   *zap.Logger
+	client   *http.Client
+
+	// initialRetryInterval and maxRetryInterval bound the backoff between attempts
+	// of a failing first load.
+	initialRetryInterval time.Duration
+	maxRetryInterval     time.Duration
+
+	mu       sync.RWMutex
+	services map[string]serviceInfo
+
+	// ctx is canceled on shutdown, to stop the background loads.
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newServiceCatalog(settings ServiceCatalogSettings, logger *zap.Logger) *serviceCatalog {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &serviceCatalog{
+		settings:             settings,
+		logger:               logger,
+		client:               &http.Client{Timeout: 30 * time.Second},
+		initialRetryInterval: time.Second,
+		maxRetryInterval:     time.Minute,
+		ctx:                  ctx,
+		cancel:               cancel,
+	}
+}
+
+// start loads the catalog, giving up when ctx is done, and keeps loading it in the
+// background: until a load succeeds when the first one failed, then every refresh
+// interval, if configured. Spans aren't enriched until a load succeeds, a failing
+// source doesn't stop the exporter.
+func (c *serviceCatalog) start(ctx context.Context) {
+	err := c.load(ctx)
+	if err != nil {
+		c.logger.Warn("Failed to load service catalog, spans are not enriched until it loads", zap.Error(err))
+	}
+	if err == nil && c.settings.RefreshInterval == 0 {
+		return
+	}
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		if err != nil && !c.retryFirstLoad() {
+			return
+		}
+		if c.settings.RefreshInterval == 0 {
+			return
+		}
+		c.refresh()
+	}()
+}
+
+// retryFirstLoad loads the catalog with exponential backoff until it succeeds. It
+// returns false when the catalog was shut down first.
+func (c *serviceCatalog) retryFirstLoad() bool {
+	interval := c.initialRetryInterval
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+		case <-c.ctx.Done():
+			return false
+		}
+		err := c.load(c.ctx)
+		if err == nil {
+			c.logger.Info("Loaded service catalog")
+			return true
+		}
+		interval *= 2
+		if interval > c.maxRetryInterval {
+			interval = c.maxRetryInterval
+		}
+		c.logger.Warn("Failed to load service catalog", zap.Duration("retryIn", interval), zap.Error(err))
+		timer.Reset(interval)
+	}
+}
+
+// refresh reloads the catalog every refresh interval until shutdown.
+func (c *serviceCatalog) refresh() {
+	ticker := time.NewTicker(c.settings.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			// Keep using the previous catalog until the source is back.
+			if err := c.load(c.ctx); err != nil {
+				c.logger.Warn("Failed to refresh service catalog", zap.Error(err))
+			}
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *serviceCatalog) shutdown() {
+	c.cancel()
+	c.wg.Wait()
+}
+
+func (c *serviceCatalog) load(ctx context.Context) error {
+	var (
+		data  []byte
+		isCSV bool
+		err   error
+	)
+	if c.settings.File != "" {
+		data, err = ioutil.ReadFile(c.settings.File)
+		isCSV = strings.EqualFold(path.Ext(c.settings.File), ".csv")
+	} else {
+		data, isCSV, err = c.fetch(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	var services map[string]serviceInfo
+	if isCSV {
+		services, err = parseServiceCatalogCSV(data)
+	} else {
+		err = yaml.Unmarshal(data, &services)
+	}
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.services = services
+	c.mu.Unlock()
+	return nil
+}
+
+// fetch gets the catalog from the endpoint and reports whether it is CSV.
+func (c *serviceCatalog) fetch(ctx context.Context) ([]byte, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.settings.Endpoint, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("request GET %s failed - %q", c.settings.Endpoint, resp.Status)
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	data, err := ioutil.ReadAll(resp.Body)
+	return data, mediaType == "text/csv", err
+}
+
+// parseServiceCatalogCSV reads a CSV catalog. The header row names the columns, only
+// the service column is required.
+func parseServiceCatalogCSV(data []byte) (map[string]serviceInfo, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("missing CSV header row")
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	serviceColumn, ok := columns["service"]
+	if !ok {
+		return nil, errors.New("missing service column in CSV header row")
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok {
+			return record[i]
+		}
+		return ""
+	}
+
+	services := make(map[string]serviceInfo, len(records)-1)
+	for _, record := range records[1:] {
+		services[record[serviceColumn]] = serviceInfo{
+			Team:  field(record, "team"),
+			Owner: field(record, "owner"),
+			Tier:  field(record, "tier"),
+		}
+	}
+	return services, nil
+}
+
+// enrich sets the team, owner and tier of the span's service.
+func (c *serviceCatalog) enrich(span *signoz.Span) {
+	c.mu.RLock()
+	info, ok := c.services[span.ServiceName]
+	c.mu.RUnlock()
+	if !ok {
+		return
+	}
+	span.Team = info.Team
+	span.Owner = info.Owner
+	span.Tier = info.Tier
+}
@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhousetracesexporter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signoz"
+)
+
+const testServiceCatalog = `
+frontend:
+  team: web
+  owner: jane@example.com
+  tier: "1"
+`
+
+func TestServiceCatalogFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(testServiceCatalog), 0600))
+
+	c := newServiceCatalog(ServiceCatalogSettings{File: path}, zap.NewNop())
+	c.start(context.Background())
+	defer c.shutdown()
+
+	span := &signoz.Span{ServiceName: "frontend"}
+	c.enrich(span)
+	assert.Equal(t, "web", span.Team)
+	assert.Equal(t, "jane@example.com", span.Owner)
+	assert.Equal(t, "1", span.Tier)
+
+	span = &signoz.Span{ServiceName: "backend"}
+	c.enrich(span)
+	assert.Empty(t, span.Team)
+}
+
+func TestServiceCatalogEndpoint(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"frontend": {"team": "web"}}`))
+	}))
+	defer srv.Close()
+
+	c := newServiceCatalog(ServiceCatalogSettings{Endpoint: srv.URL}, zap.NewNop())
+	c.start(context.Background())
+	defer c.shutdown()
+
+	span := &signoz.Span{ServiceName: "frontend"}
+	c.enrich(span)
+	assert.Equal(t, "web", span.Team)
+}
+
+func TestServiceCatalogEndpointRetry(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The source is down when the exporter starts, and for the first retry.
+		if atomic.AddInt32(&requests, 1) <= 2 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"frontend": {"team": "web"}}`))
+	}))
+	defer srv.Close()
+
+	// The first load is retried even though the catalog is not refreshed.
+	c := newServiceCatalog(ServiceCatalogSettings{Endpoint: srv.URL}, zap.NewNop())
+	c.initialRetryInterval = 10 * time.Millisecond
+	c.maxRetryInterval = 20 * time.Millisecond
+	c.start(context.Background())
+	defer c.shutdown()
+
+	span := &signoz.Span{ServiceName: "frontend"}
+	c.enrich(span)
+	assert.Empty(t, span.Team)
+
+	assert.Eventually(t, func() bool {
+		span := &signoz.Span{ServiceName: "frontend"}
+		c.enrich(span)
+		return span.Team == "web"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestServiceCatalogStartCanceled(t *testing.T) {
+	unblock := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-unblock:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(unblock)
+
+	c := newServiceCatalog(ServiceCatalogSettings{Endpoint: srv.URL}, zap.NewNop())
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	c.start(ctx)
+	assert.Less(t, int64(time.Since(start)), int64(5*time.Second))
+
+	// Shutdown stops the retries of the first load.
+	c.shutdown()
+}
+
+func TestServiceCatalogCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.csv")
+	require.NoError(t, ioutil.WriteFile(path, []byte("service, team, owner, tier\nfrontend, web, jane@example.com, 1\n"), 0600))
+
+	c := newServiceCatalog(ServiceCatalogSettings{File: path}, zap.NewNop())
+	c.start(context.Background())
+	defer c.shutdown()
+
+	span := &signoz.Span{ServiceName: "frontend"}
+	c.enrich(span)
+	assert.Equal(t, "web", span.Team)
+	assert.Equal(t, "jane@example.com", span.Owner)
+	assert.Equal(t, "1", span.Tier)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		_, _ = w.Write([]byte("tier,service\n2,backend\n"))
+	}))
+	defer srv.Close()
+
+	c = newServiceCatalog(ServiceCatalogSettings{Endpoint: srv.URL}, zap.NewNop())
+	c.start(context.Background())
+	defer c.shutdown()
+
+	span = &signoz.Span{ServiceName: "backend"}
+	c.enrich(span)
+	assert.Empty(t, span.Team)
+	assert.Equal(t, "2", span.Tier)
+}
+
+func TestParseServiceCatalogCSVErrors(t *testing.T) {
+	_, err := parseServiceCatalogCSV(nil)
+	assert.EqualError(t, err, "missing CSV header row")
+	_, err = parseServiceCatalogCSV([]byte("name,team\nfrontend,web\n"))
+	assert.EqualError(t, err, "missing service column in CSV header row")
+	_, err = parseServiceCatalogCSV([]byte("service,team\nfrontend\n"))
+	assert.Error(t, err)
+}
+
+func TestServiceCatalogSettingsValidate(t *testing.T) {
+	assert.NoError(t, ServiceCatalogSettings{File: "catalog.yaml"}.validate())
+	assert.Error(t, ServiceCatalogSettings{File: "catalog.yaml", Endpoint: "http://localhost"}.validate())
+	assert.Error(t, ServiceCatalogSettings{RefreshInterval: -1}.validate())
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhousetracesexporter
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// DedupSettings skips spans whose trace and span ID were already exported recently, so
+// batches redelivered by upstream retries aren't counted twice.
+type DedupSettings struct {
+	// Window is how long the IDs of an exported span are remembered. Zero disables deduplication.
+	Window time.Duration `mapstructure:"window"`
+	// MaxEntries caps the IDs remembered (default 100000); the least recently seen are
+	// forgotten first.
+	MaxEntries int `mapstructure:"max_entries"`
+}
+
+func (s DedupSettings) validate() error {
+	if s.Window < 0 {
+		return fmt.Errorf("dedup.window must not be negative. configured value %v", s.Window)
+	}
+	if s.Window > 0 && s.MaxEntries <= 0 {
+		return fmt.Errorf("dedup.max_entries must be positive. configured value %v", s.MaxEntries)
+	}
+	return nil
+}
+
+type spanKey struct {
+	traceID pdata.TraceID
+	spanID  pdata.SpanID
+}
+
+type dedupEntry struct {
+	key  spanKey
+	seen time.Time
+}
+
+// spanDeduplicator is an LRU of the IDs of recently exported spans. The list is
+// ordered by the time the IDs were last seen, most recent first.
+type spanDeduplicator struct {
+	window     time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries map[spanKey]*list.Element
+	order   *list.List
+}
+
+func newSpanDeduplicator(settings DedupSettings) *spanDeduplicator {
+	return &spanDeduplicator{
+		window:     settings.Window,
+		maxEntries: settings.MaxEntries,
+		now:        time.Now,
+		entries:    make(map[spanKey]*list.Element),
+		order:      list.New(),
+	}
+}
+
+// seen reports whether the span was already seen within the window, and remembers it.
+func (d *spanDeduplicator) seen(traceID pdata.TraceID, spanID pdata.SpanID) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	for back := d.order.Back(); back != nil && now.Sub(back.Value.(*dedupEntry).seen) >= d.window; back = d.order.Back() {
+		d.remove(back)
+	}
+
+	key := spanKey{traceID: traceID, spanID: spanID}
+	if el, ok := d.entries[key]; ok {
+		el.Value.(*dedupEntry).seen = now
+		d.order.MoveToFront(el)
+		return true
+	}
+	d.entries[key] = d.order.PushFront(&dedupEntry{key: key, seen: now})
+	if d.order.Len() > d.maxEntries {
+		d.remove(d.order.Back())
+	}
+	return false
+}
+
+func (d *spanDeduplicator) remove(el *list.Element) {
+	d.order.Remove(el)
+	delete(d.entries, el.Value.(*dedupEntry).key)
+}
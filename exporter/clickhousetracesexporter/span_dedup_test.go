@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhousetracesexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestSpanDeduplicator(t *testing.T) {
+	d := newSpanDeduplicator(DedupSettings{Window: time.Minute, MaxEntries: 2})
+	now := time.Unix(1000, 0)
+	d.now = func() time.Time { return now }
+
+	traceID := pdata.NewTraceID([16]byte{1})
+	first := pdata.NewSpanID([8]byte{1})
+	second := pdata.NewSpanID([8]byte{2})
+	third := pdata.NewSpanID([8]byte{3})
+
+	assert.False(t, d.seen(traceID, first))
+	assert.True(t, d.seen(traceID, first))
+	assert.False(t, d.seen(pdata.NewTraceID([16]byte{2}), first))
+
+	// The least recently seen span is forgotten once max_entries is exceeded.
+	assert.False(t, d.seen(traceID, second))
+	assert.False(t, d.seen(traceID, first))
+
+	// Spans are forgotten once the window passed since they were last seen.
+	now = now.Add(30 * time.Second)
+	assert.False(t, d.seen(traceID, third))
+	now = now.Add(30 * time.Second)
+	assert.False(t, d.seen(traceID, first))
+	assert.True(t, d.seen(traceID, third))
+}
+
+func TestDedupSettingsValidate(t *testing.T) {
+	assert.NoError(t, DedupSettings{MaxEntries: 0}.validate())
+	assert.NoError(t, DedupSettings{Window: time.Minute, MaxEntries: 10}.validate())
+	assert.EqualError(t, DedupSettings{Window: -time.Minute}.validate(), "dedup.window must not be negative. configured value -1m0s")
+	assert.EqualError(t, DedupSettings{Window: time.Minute}.validate(), "dedup.max_entries must be positive. configured value 0")
+}
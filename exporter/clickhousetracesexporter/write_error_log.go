@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhousetracesexporter
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signoz"
+)
+
+// writeErrorLogInterval is the minimum time between two logged write failures of a table.
+const writeErrorLogInterval = 10 * time.Second
+
+// writeErrorLogger logs failed writes with the context needed to act on them. It logs
+// at most one failure per table and interval, and reports the failures suppressed in
+// between with the next one logged.
+type writeErrorLogger struct {
+	logger   *zap.Logger
+	interval time.Duration
+	now      func() time.Time
+
+	mu         sync.Mutex
+	last       map[string]time.Time
+	suppressed map[string]int
+}
+
+func newWriteErrorLogger(logger *zap.Logger) *writeErrorLogger {
+	return &writeErrorLogger{
+		logger:     logger,
+		interval:   writeErrorLogInterval,
+		now:        time.Now,
+		last:       make(map[string]time.Time),
+		suppressed: make(map[string]int),
+	}
+}
+
+// log logs that writing batch to table failed with err, unless a failure of the
+// table was logged less than an interval ago. Failures that don't belong to a table,
+// such as queueing spans, pass an empty table.
+func (l *writeErrorLogger) log(msg string, table string, batch []*signoz.Span, err error) {
+	l.mu.Lock()
+	now := l.now()
+	if last, ok := l.last[table]; ok && now.Sub(last) < l.interval {
+		l.suppressed[table]++
+		l.mu.Unlock()
+		return
+	}
+	suppressed := l.suppressed[table]
+	l.last[table] = now
+	delete(l.suppressed, table)
+	l.mu.Unlock()
+
+	var fields []zap.Field
+	if table != "" {
+		fields = append(fields, zap.String("table", table))
+	}
+	fields = append(fields,
+		zap.Int("batchSize", len(batch)),
+		zap.String("errorClass", errorClass(err)),
+		zap.Int("suppressed", suppressed),
+		zap.Error(err),
+	)
+	var writeErr *WriteError
+	if errors.As(err, &writeErr) {
+		fields = append(fields, zap.String("stage", string(writeErr.Stage)))
+	}
+	if len(batch) > 0 {
+		fields = append(fields,
+			zap.String("traceID", batch[0].TraceId),
+			zap.String("spanID", batch[0].SpanId),
+			zap.String("serviceName", batch[0].ServiceName),
+		)
+	}
+	l.logger.Error(msg, fields...)
+}
+
+// errorClass names the kind of a write failure: the ClickHouse exception name for
+// errors returned by the server, "timeout" or "network" for connection problems and
+// "other" for everything else.
+func errorClass(err error) string {
+	var exception *clickhouse.Exception
+	if errors.As(err, &exception) {
+		if exception.Name != "" {
+			return exception.Name
+		}
+		return "exception"
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout"
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return "timeout"
+		}
+		return "network"
+	}
+	return "other"
+}
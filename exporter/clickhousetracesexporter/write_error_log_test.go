@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhousetracesexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signoz"
+)
+
+func TestWriteErrorLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	l := newWriteErrorLogger(zap.New(core))
+	now := time.Unix(1000, 0)
+	l.now = func() time.Time { return now }
+
+	batch := []*signoz.Span{
+		{TraceId: "0102", SpanId: "03", ServiceName: "frontend"},
+		{TraceId: "0405", SpanId: "06", ServiceName: "backend"},
+	}
+	err := &clickhouse.Exception{Code: 60, Name: "UNKNOWN_TABLE", Message: "table doesn't exist"}
+	l.log("Could not write a batch of spans", "signoz_index_v2", batch, err)
+	l.log("Could not write a batch of spans", "signoz_index_v2", batch, err)
+	l.log("Could not write a batch of spans", "signoz_error_index_v2", batch, err)
+	now = now.Add(writeErrorLogInterval)
+	l.log("Could not write a batch of spans", "signoz_index_v2", batch[1:], err)
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 3)
+	assert.Equal(t, map[string]interface{}{
+		"table":       "signoz_index_v2",
+		"batchSize":   int64(2),
+		"errorClass":  "UNKNOWN_TABLE",
+		"suppressed":  int64(0),
+		"error":       err.Error(),
+		"traceID":     "0102",
+		"spanID":      "03",
+		"serviceName": "frontend",
+	}, entries[0].ContextMap())
+	assert.Equal(t, "signoz_error_index_v2", entries[1].ContextMap()["table"])
+	assert.Equal(t, int64(1), entries[2].ContextMap()["suppressed"])
+	assert.Equal(t, "backend", entries[2].ContextMap()["serviceName"])
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestErrorClass(t *testing.T) {
+	assert.Equal(t, "MEMORY_LIMIT_EXCEEDED", errorClass(fmt.Errorf("send: %w", &clickhouse.Exception{Name: "MEMORY_LIMIT_EXCEEDED"})))
+	assert.Equal(t, "timeout", errorClass(context.DeadlineExceeded))
+	assert.Equal(t, "timeout", errorClass(timeoutError{}))
+	assert.Equal(t, "other", errorClass(errors.New("boom")))
+}
@@ -16,14 +16,16 @@ package clickhousetracesexporter
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"strings"
 	"sync"
 	"time"
 
 	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/mailru/easyjson"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signoz"
 )
 
 type Encoding string
@@ -35,6 +37,59 @@ const (
 	EncodingProto Encoding = "protobuf"
 )
 
+// TimeColumnSource selects the span time stored in the timestamp column of the index
+// and model rows.
+type TimeColumnSource string
+
+const (
+	// TimeColumnSourceStart stores the start time of spans.
+	TimeColumnSourceStart TimeColumnSource = "start"
+	// TimeColumnSourceEnd stores the end time of spans, so rows are partitioned by
+	// completion time.
+	TimeColumnSourceEnd TimeColumnSource = "end"
+)
+
+// schemaVersion is stored with every index, model and error row, so readers and
+// migration jobs can tell row layouts apart after upgrades. Bump it whenever the
+// columns written or the model change.
+const schemaVersion uint16 = 12
+
+// WriteStage is the step of writing a batch of rows that failed.
+type WriteStage string
+
+const (
+	// WriteStagePrepare is preparing the INSERT statement, which needs the connection.
+	WriteStagePrepare WriteStage = "prepare"
+	// WriteStageSerialize is serializing a span to the trace model.
+	WriteStageSerialize WriteStage = "serialize"
+	// WriteStageAppend is appending a row to the batch, which fails when the row
+	// doesn't match the columns of the table.
+	WriteStageAppend WriteStage = "append"
+	// WriteStageSend is sending the batch to ClickHouse.
+	WriteStageSend WriteStage = "send"
+)
+
+// WriteError is returned when writing a batch of rows to a table fails, so callers can
+// branch on the stage and class of the failure instead of matching error strings.
+type WriteError struct {
+	Table string
+	Stage WriteStage
+	Err   error
+}
+
+func (e *WriteError) Error() string {
+	return fmt.Sprintf("%s rows of %s: %v", e.Stage, e.Table, e.Err)
+}
+
+func (e *WriteError) Unwrap() error {
+	return e.Err
+}
+
+// Class names the kind of the failure, see errorClass.
+func (e *WriteError) Class() string {
+	return errorClass(e.Err)
+}
+
 // SpanWriter for writing spans to ClickHouse
 type SpanWriter struct {
 	logger        *zap.Logger
@@ -42,29 +97,40 @@ type SpanWriter struct {
 	traceDatabase string
 	indexTable    string
 	errorTable    string
-	spansTable    string
-	encoding      Encoding
-	delay         time.Duration
-	size          int
-	spans         chan *Span
-	finish        chan bool
-	done          sync.WaitGroup
+	eventsTable   string
+	linksTable    string
+	// exceptionLogsTable gets an ERROR log row per exception event.
+	exceptionLogsTable string
+	spansTable         string
+	encoding           Encoding
+	timeSource         TimeColumnSource
+	delay              time.Duration
+	size               int
+	spans              chan *signoz.Span
+	finish             chan bool
+	done               sync.WaitGroup
+	writeErrors        *writeErrorLogger
 }
 
 // NewSpanWriter returns a SpanWriter for the database
-func NewSpanWriter(logger *zap.Logger, db clickhouse.Conn, traceDatabase string, spansTable string, indexTable string, errorTable string, encoding Encoding, delay time.Duration, size int) *SpanWriter {
+func NewSpanWriter(logger *zap.Logger, db clickhouse.Conn, traceDatabase string, spansTable string, indexTable string, errorTable string, eventsTable string, linksTable string, exceptionLogsTable string, encoding Encoding, timeSource TimeColumnSource, delay time.Duration, size int) *SpanWriter {
 	writer := &SpanWriter{
-		logger:        logger,
-		db:            db,
-		traceDatabase: traceDatabase,
-		indexTable:    indexTable,
-		errorTable:    errorTable,
-		spansTable:    spansTable,
-		encoding:      encoding,
-		delay:         delay,
-		size:          size,
-		spans:         make(chan *Span, size),
-		finish:        make(chan bool),
+		logger:             logger,
+		db:                 db,
+		traceDatabase:      traceDatabase,
+		indexTable:         indexTable,
+		errorTable:         errorTable,
+		eventsTable:        eventsTable,
+		linksTable:         linksTable,
+		exceptionLogsTable: exceptionLogsTable,
+		spansTable:         spansTable,
+		encoding:           encoding,
+		timeSource:         timeSource,
+		delay:              delay,
+		size:               size,
+		spans:              make(chan *signoz.Span, size),
+		finish:             make(chan bool),
+		writeErrors:        newWriteErrorLogger(logger),
 	}
 
 	go writer.backgroundWriter()
@@ -73,7 +139,7 @@ func NewSpanWriter(logger *zap.Logger, db clickhouse.Conn, traceDatabase string,
 }
 
 func (w *SpanWriter) backgroundWriter() {
-	batch := make([]*Span, 0, w.size)
+	batch := make([]*signoz.Span, 0, w.size)
 
 	timer := time.After(w.delay)
 	last := time.Now()
@@ -98,10 +164,15 @@ func (w *SpanWriter) backgroundWriter() {
 
 		if flush {
 			if err := w.writeBatch(batch); err != nil {
-				w.logger.Error("Could not write a batch of spans", zap.Error(err))
+				table := ""
+				var writeErr *WriteError
+				if errors.As(err, &writeErr) {
+					table = writeErr.Table
+				}
+				w.writeErrors.log("Could not write a batch of spans", table, batch, err)
 			}
 
-			batch = make([]*Span, 0, w.size)
+			batch = make([]*signoz.Span, 0, w.size)
 			last = time.Now()
 		}
 
@@ -113,20 +184,53 @@ func (w *SpanWriter) backgroundWriter() {
 	}
 }
 
-func (w *SpanWriter) writeBatch(batch []*Span) error {
+func (w *SpanWriter) writeBatch(batch []*signoz.Span) error {
 
 	if w.spansTable != "" {
-		if err := w.writeModelBatch(batch); err != nil {
+		start := time.Now()
+		err := w.writeModelBatch(batch)
+		recordWriteLatency(w.spansTable, time.Since(start), err)
+		if err != nil {
 			return err
 		}
 	}
 	if w.indexTable != "" {
-		if err := w.writeIndexBatch(batch); err != nil {
+		start := time.Now()
+		err := w.writeIndexBatch(batch)
+		recordWriteLatency(w.indexTable, time.Since(start), err)
+		if err != nil {
 			return err
 		}
 	}
 	if w.errorTable != "" {
-		if err := w.writeErrorBatch(batch); err != nil {
+		start := time.Now()
+		err := w.writeErrorBatch(batch)
+		recordWriteLatency(w.errorTable, time.Since(start), err)
+		if err != nil {
+			return err
+		}
+	}
+	if w.eventsTable != "" {
+		start := time.Now()
+		err := w.writeEventBatch(batch)
+		recordWriteLatency(w.eventsTable, time.Since(start), err)
+		if err != nil {
+			return err
+		}
+	}
+	if w.linksTable != "" {
+		start := time.Now()
+		err := w.writeLinkBatch(batch)
+		recordWriteLatency(w.linksTable, time.Since(start), err)
+		if err != nil {
+			return err
+		}
+	}
+	if w.exceptionLogsTable != "" {
+		start := time.Now()
+		err := w.writeExceptionLogBatch(batch)
+		recordWriteLatency(w.exceptionLogsTable, time.Since(start), err)
+		if err != nil {
 			return err
 		}
 	}
@@ -134,17 +238,17 @@ func (w *SpanWriter) writeBatch(batch []*Span) error {
 	return nil
 }
 
-func (w *SpanWriter) writeIndexBatch(batchSpans []*Span) error {
+func (w *SpanWriter) writeIndexBatch(batchSpans []*signoz.Span) error {
 
 	ctx := context.Background()
 	statement, err := w.db.PrepareBatch(ctx, fmt.Sprintf("INSERT INTO %s.%s", w.traceDatabase, w.indexTable))
 	if err != nil {
-		return err
+		return &WriteError{Table: w.indexTable, Stage: WriteStagePrepare, Err: err}
 	}
 
 	for _, span := range batchSpans {
 		err = statement.Append(
-			time.Unix(0, int64(span.StartTimeUnixNano)),
+			spanTimestamp(span, w.timeSource),
 			span.TraceId,
 			span.SpanId,
 			span.ParentSpanId,
@@ -176,21 +280,51 @@ func (w *SpanWriter) writeIndexBatch(batchSpans []*Span) error {
 			span.RPCService,
 			span.RPCMethod,
 			span.ResponseStatusCode,
+			span.GRPCStatusName,
+			span.HttpPath,
+			span.Team,
+			span.Owner,
+			span.Tier,
+			span.HttpRequestSize,
+			span.HttpResponseSize,
+			span.ProfileID,
+			schemaVersion,
+			span.CollectorHostname,
+			span.CollectorInstanceID,
+			span.K8sNamespaceName,
+			span.K8sPodName,
+			span.K8sDeploymentName,
+			span.K8sNodeName,
+			span.CloudProvider,
+			span.CloudRegion,
+			span.CloudAvailabilityZone,
+			span.CloudAccountID,
+			span.ContainerID,
+			span.ContainerImageName,
+			span.ContainerImageTag,
+			span.HostName,
+			span.OSType,
+			span.SpanHash,
+			span.LateArrival,
+			span.OriginalStartTimeUnixNano,
 		)
 		if err != nil {
-			return err
+			return &WriteError{Table: w.indexTable, Stage: WriteStageAppend, Err: err}
 		}
 	}
 
-	return statement.Send()
+	if err := statement.Send(); err != nil {
+		return &WriteError{Table: w.indexTable, Stage: WriteStageSend, Err: err}
+	}
+	return nil
 }
 
-func (w *SpanWriter) writeErrorBatch(batchSpans []*Span) error {
+func (w *SpanWriter) writeErrorBatch(batchSpans []*signoz.Span) error {
 
 	ctx := context.Background()
 	statement, err := w.db.PrepareBatch(ctx, fmt.Sprintf("INSERT INTO %s.%s", w.traceDatabase, w.errorTable))
 	if err != nil {
-		return err
+		return &WriteError{Table: w.errorTable, Stage: WriteStagePrepare, Err: err}
 	}
 
 	for _, span := range batchSpans {
@@ -207,50 +341,174 @@ func (w *SpanWriter) writeErrorBatch(batchSpans []*Span) error {
 			span.ErrorEvent.AttributeMap["exception.type"],
 			span.ErrorEvent.AttributeMap["exception.message"],
 			span.ErrorEvent.AttributeMap["exception.stacktrace"],
-			stringToBool(span.ErrorEvent.AttributeMap["exception.escaped"]),
+			span.ErrorEscaped,
+			span.ErrorDropped,
+			schemaVersion,
+			span.CollectorHostname,
+			span.CollectorInstanceID,
 		)
 		if err != nil {
-			return err
+			return &WriteError{Table: w.errorTable, Stage: WriteStageAppend, Err: err}
 		}
 	}
 
-	return statement.Send()
+	if err := statement.Send(); err != nil {
+		return &WriteError{Table: w.errorTable, Stage: WriteStageSend, Err: err}
+	}
+	return nil
 }
 
-func stringToBool(s string) bool {
-	if strings.ToLower(s) == "true" {
-		return true
+func (w *SpanWriter) writeEventBatch(batchSpans []*signoz.Span) error {
+
+	ctx := context.Background()
+	statement, err := w.db.PrepareBatch(ctx, fmt.Sprintf("INSERT INTO %s.%s", w.traceDatabase, w.eventsTable))
+	if err != nil {
+		return &WriteError{Table: w.eventsTable, Stage: WriteStagePrepare, Err: err}
 	}
-	return false
+
+	for _, span := range batchSpans {
+		for _, event := range span.SpanEvents {
+			err = statement.Append(
+				time.Unix(0, int64(event.TimeUnixNano)),
+				span.TraceId,
+				span.SpanId,
+				span.ServiceName,
+				event.Name,
+				event.AttributeMap,
+				schemaVersion,
+				span.CollectorHostname,
+				span.CollectorInstanceID,
+			)
+			if err != nil {
+				return &WriteError{Table: w.eventsTable, Stage: WriteStageAppend, Err: err}
+			}
+		}
+	}
+
+	if err := statement.Send(); err != nil {
+		return &WriteError{Table: w.eventsTable, Stage: WriteStageSend, Err: err}
+	}
+	return nil
+}
+
+func (w *SpanWriter) writeLinkBatch(batchSpans []*signoz.Span) error {
+
+	ctx := context.Background()
+	statement, err := w.db.PrepareBatch(ctx, fmt.Sprintf("INSERT INTO %s.%s", w.traceDatabase, w.linksTable))
+	if err != nil {
+		return &WriteError{Table: w.linksTable, Stage: WriteStagePrepare, Err: err}
+	}
+
+	for _, span := range batchSpans {
+		for _, link := range span.SpanLinks {
+			err = statement.Append(
+				spanTimestamp(span, w.timeSource),
+				span.TraceId,
+				span.SpanId,
+				span.ServiceName,
+				link.TraceId,
+				link.SpanId,
+				link.AttributeMap,
+				schemaVersion,
+				span.CollectorHostname,
+				span.CollectorInstanceID,
+			)
+			if err != nil {
+				return &WriteError{Table: w.linksTable, Stage: WriteStageAppend, Err: err}
+			}
+		}
+	}
+
+	if err := statement.Send(); err != nil {
+		return &WriteError{Table: w.linksTable, Stage: WriteStageSend, Err: err}
+	}
+	return nil
+}
+
+// Severity of the exception log rows, as defined by the OpenTelemetry log data model.
+const (
+	exceptionSeverityText   = "ERROR"
+	exceptionSeverityNumber = uint8(17)
+)
+
+// writeExceptionLogBatch writes a log row for every exception event, so that log
+// centric workflows see the errors of the traces without shipping them as logs too.
+func (w *SpanWriter) writeExceptionLogBatch(batchSpans []*signoz.Span) error {
+
+	ctx := context.Background()
+	statement, err := w.db.PrepareBatch(ctx, fmt.Sprintf("INSERT INTO %s.%s", w.traceDatabase, w.exceptionLogsTable))
+	if err != nil {
+		return &WriteError{Table: w.exceptionLogsTable, Stage: WriteStagePrepare, Err: err}
+	}
+
+	for _, span := range batchSpans {
+		for _, event := range span.SpanEvents {
+			if !event.IsError {
+				continue
+			}
+			err = statement.Append(
+				time.Unix(0, int64(event.TimeUnixNano)),
+				span.TraceId,
+				span.SpanId,
+				span.ServiceName,
+				exceptionSeverityText,
+				exceptionSeverityNumber,
+				event.AttributeMap["exception.message"],
+				event.AttributeMap,
+				schemaVersion,
+				span.CollectorHostname,
+				span.CollectorInstanceID,
+			)
+			if err != nil {
+				return &WriteError{Table: w.exceptionLogsTable, Stage: WriteStageAppend, Err: err}
+			}
+		}
+	}
+
+	if err := statement.Send(); err != nil {
+		return &WriteError{Table: w.exceptionLogsTable, Stage: WriteStageSend, Err: err}
+	}
+	return nil
 }
 
-func (w *SpanWriter) writeModelBatch(batchSpans []*Span) error {
+func (w *SpanWriter) writeModelBatch(batchSpans []*signoz.Span) error {
 	ctx := context.Background()
 	statement, err := w.db.PrepareBatch(ctx, fmt.Sprintf("INSERT INTO %s.%s", w.traceDatabase, w.spansTable))
 	if err != nil {
-		return err
+		return &WriteError{Table: w.spansTable, Stage: WriteStagePrepare, Err: err}
 	}
 
 	for _, span := range batchSpans {
 		var serialized []byte
 
-		serialized, err = json.Marshal(span.TraceModel)
+		serialized, err = easyjson.Marshal(span.TraceModel)
 
 		if err != nil {
-			return err
+			return &WriteError{Table: w.spansTable, Stage: WriteStageSerialize, Err: err}
 		}
 
-		err = statement.Append(time.Unix(0, int64(span.StartTimeUnixNano)), span.TraceId, string(serialized))
+		err = statement.Append(spanTimestamp(span, w.timeSource), span.TraceId, string(serialized), schemaVersion, span.CollectorHostname, span.CollectorInstanceID)
 		if err != nil {
-			return err
+			return &WriteError{Table: w.spansTable, Stage: WriteStageAppend, Err: err}
 		}
 	}
 
-	return statement.Send()
+	if err := statement.Send(); err != nil {
+		return &WriteError{Table: w.spansTable, Stage: WriteStageSend, Err: err}
+	}
+	return nil
+}
+
+// spanTimestamp returns the time of span stored in the timestamp column.
+func spanTimestamp(span *signoz.Span, source TimeColumnSource) time.Time {
+	if source == TimeColumnSourceEnd {
+		return time.Unix(0, int64(span.StartTimeUnixNano+span.DurationNano))
+	}
+	return time.Unix(0, int64(span.StartTimeUnixNano))
 }
 
 // WriteSpan writes the encoded span
-func (w *SpanWriter) WriteSpan(span *Span) error {
+func (w *SpanWriter) WriteSpan(span *signoz.Span) error {
 	w.spans <- span
 	return nil
 }
@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhousetracesexporter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signoz"
+)
+
+func TestSpanTimestamp(t *testing.T) {
+	span := &signoz.Span{StartTimeUnixNano: 1000, DurationNano: 500}
+	assert.Equal(t, time.Unix(0, 1000), spanTimestamp(span, TimeColumnSourceStart))
+	assert.Equal(t, time.Unix(0, 1000), spanTimestamp(span, ""))
+	assert.Equal(t, time.Unix(0, 1500), spanTimestamp(span, TimeColumnSourceEnd))
+}
+
+// failingConn fails writes at the configured stage.
+type failingConn struct {
+	clickhouse.Conn
+	stage WriteStage
+}
+
+func (c *failingConn) PrepareBatch(context.Context, string) (driver.Batch, error) {
+	if c.stage == WriteStagePrepare {
+		return nil, errors.New("connection refused")
+	}
+	return &failingBatch{stage: c.stage}, nil
+}
+
+type failingBatch struct {
+	driver.Batch
+	stage WriteStage
+}
+
+func (b *failingBatch) Append(...interface{}) error {
+	if b.stage == WriteStageAppend {
+		return errors.New("unexpected column count")
+	}
+	return nil
+}
+
+func (b *failingBatch) Send() error {
+	if b.stage == WriteStageSend {
+		return &clickhouse.Exception{Code: 241, Name: "MEMORY_LIMIT_EXCEEDED"}
+	}
+	return nil
+}
+
+func TestWriteBatchError(t *testing.T) {
+	batch := []*signoz.Span{{TraceId: "0102", SpanId: "03"}}
+	for _, stage := range []WriteStage{WriteStagePrepare, WriteStageAppend, WriteStageSend} {
+		t.Run(string(stage), func(t *testing.T) {
+			w := &SpanWriter{
+				logger:        zap.NewNop(),
+				db:            &failingConn{stage: stage},
+				traceDatabase: "signoz_traces",
+				indexTable:    "signoz_index_v2",
+			}
+			err := w.writeBatch(batch)
+			var writeErr *WriteError
+			require.True(t, errors.As(err, &writeErr))
+			assert.Equal(t, "signoz_index_v2", writeErr.Table)
+			assert.Equal(t, stage, writeErr.Stage)
+		})
+	}
+
+	w := &SpanWriter{db: &failingConn{stage: WriteStageSend}, traceDatabase: "signoz_traces", spansTable: "signoz_spans"}
+	err := w.writeBatch(batch)
+	assert.EqualError(t, err, "send rows of signoz_spans: code: 241, message: ")
+	var writeErr *WriteError
+	require.True(t, errors.As(err, &writeErr))
+	assert.Equal(t, "MEMORY_LIMIT_EXCEEDED", writeErr.Class())
+
+	w = &SpanWriter{db: &failingConn{}, traceDatabase: "signoz_traces", spansTable: "signoz_spans"}
+	assert.NoError(t, w.writeBatch(batch))
+}
+
+// recordingConn records the rows appended to the batches it prepares.
+type recordingConn struct {
+	clickhouse.Conn
+	queries []string
+	rows    [][]interface{}
+}
+
+func (c *recordingConn) PrepareBatch(_ context.Context, query string) (driver.Batch, error) {
+	c.queries = append(c.queries, query)
+	return &recordingBatch{conn: c}, nil
+}
+
+type recordingBatch struct {
+	driver.Batch
+	conn *recordingConn
+}
+
+func (b *recordingBatch) Append(v ...interface{}) error {
+	b.conn.rows = append(b.conn.rows, v)
+	return nil
+}
+
+func (b *recordingBatch) Send() error {
+	return nil
+}
+
+func TestWriteEventBatch(t *testing.T) {
+	conn := &recordingConn{}
+	w := &SpanWriter{db: conn, traceDatabase: "signoz_traces", eventsTable: "signoz_span_events"}
+	batch := []*signoz.Span{
+		{
+			TraceId:           "0102",
+			SpanId:            "03",
+			ServiceName:       "frontend",
+			CollectorHostname: "collector-1",
+			SpanEvents: []signoz.Event{
+				{Name: "cache.miss", TimeUnixNano: 1000, AttributeMap: map[string]string{"key": "orders"}},
+				{Name: "retry", TimeUnixNano: 2000},
+			},
+		},
+		{TraceId: "0405", SpanId: "06"},
+	}
+	require.NoError(t, w.writeBatch(batch))
+
+	assert.Equal(t, []string{"INSERT INTO signoz_traces.signoz_span_events"}, conn.queries)
+	require.Len(t, conn.rows, 2)
+	assert.Equal(t, []interface{}{
+		time.Unix(0, 1000), "0102", "03", "frontend", "cache.miss", map[string]string{"key": "orders"},
+		schemaVersion, "collector-1", "",
+	}, conn.rows[0])
+	assert.Equal(t, "retry", conn.rows[1][4])
+}
+
+func TestWriteLinkBatch(t *testing.T) {
+	conn := &recordingConn{}
+	w := &SpanWriter{db: conn, traceDatabase: "signoz_traces", linksTable: "signoz_span_links"}
+	batch := []*signoz.Span{
+		{
+			TraceId:           "0102",
+			SpanId:            "03",
+			ServiceName:       "consumer",
+			StartTimeUnixNano: 1000,
+			SpanLinks: []signoz.Link{
+				{TraceId: "0405", SpanId: "06", AttributeMap: map[string]string{"batch.index": "0"}},
+				{TraceId: "0708", SpanId: "09"},
+			},
+		},
+		{TraceId: "0a0b", SpanId: "0c"},
+	}
+	require.NoError(t, w.writeBatch(batch))
+
+	assert.Equal(t, []string{"INSERT INTO signoz_traces.signoz_span_links"}, conn.queries)
+	require.Len(t, conn.rows, 2)
+	assert.Equal(t, []interface{}{
+		time.Unix(0, 1000), "0102", "03", "consumer", "0405", "06", map[string]string{"batch.index": "0"},
+		schemaVersion, "", "",
+	}, conn.rows[0])
+	assert.Equal(t, "0708", conn.rows[1][4])
+}
+
+func TestWriteExceptionLogBatch(t *testing.T) {
+	conn := &recordingConn{}
+	w := &SpanWriter{db: conn, traceDatabase: "signoz_traces", exceptionLogsTable: "signoz_exception_logs"}
+	exception := map[string]string{"exception.type": "TimeoutError", "exception.message": "deadline exceeded"}
+	batch := []*signoz.Span{
+		{
+			TraceId:     "0102",
+			SpanId:      "03",
+			ServiceName: "frontend",
+			SpanEvents: []signoz.Event{
+				{Name: "cache.miss", TimeUnixNano: 1000},
+				{Name: "exception", TimeUnixNano: 2000, AttributeMap: exception, IsError: true},
+			},
+		},
+		{TraceId: "0405", SpanId: "06"},
+	}
+	require.NoError(t, w.writeBatch(batch))
+
+	assert.Equal(t, []string{"INSERT INTO signoz_traces.signoz_exception_logs"}, conn.queries)
+	require.Len(t, conn.rows, 1)
+	assert.Equal(t, []interface{}{
+		time.Unix(0, 2000), "0102", "03", "frontend", "ERROR", uint8(17), "deadline exceeded", exception,
+		schemaVersion, "", "",
+	}, conn.rows[0])
+}
@@ -31,6 +31,7 @@ func TestDefaultTracesMarshalers(t *testing.T) {
 		"otlp_json",
 		"jaeger_proto",
 		"jaeger_json",
+		"signoz",
 	}
 	marshalers := tracesMarshalers()
 	assert.Equal(t, len(expectedEncodings), len(marshalers))
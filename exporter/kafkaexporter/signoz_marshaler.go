@@ -0,0 +1,65 @@
+// Copyright 2020 The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+
+import (
+	"github.com/Shopify/sarama"
+	"github.com/mailru/easyjson"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signoz"
+)
+
+// signozMarshaler serializes every span to a single SigNoz index row, the same row the
+// ClickHouse traces exporter writes, so the topic can be ingested by SigNoz storage directly.
+type signozMarshaler struct {
+}
+
+var _ TracesMarshaler = (*signozMarshaler)(nil)
+
+func (s signozMarshaler) Marshal(traces pdata.Traces, topic string) ([]*sarama.ProducerMessage, error) {
+	var messages []*sarama.ProducerMessage
+
+	var errs error
+	rss := traces.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		serviceName := signoz.ServiceNameForResource(rs.Resource())
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := signoz.NewStructuredSpan(spans.At(k), serviceName, rs.Resource(), signoz.Options{})
+				bts, err := easyjson.Marshal(span)
+				// continue to process spans that can be serialized
+				if err != nil {
+					errs = multierr.Append(errs, err)
+					continue
+				}
+				messages = append(messages, &sarama.ProducerMessage{
+					Topic: topic,
+					Value: sarama.ByteEncoder(bts),
+					Key:   sarama.ByteEncoder([]byte(span.TraceId)),
+				})
+			}
+		}
+	}
+	return messages, errs
+}
+
+func (s signozMarshaler) Encoding() string {
+	return "signoz"
+}
@@ -0,0 +1,59 @@
+// Copyright 2020 The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/mailru/easyjson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signoz"
+)
+
+func TestSignozMarshaler(t *testing.T) {
+	td := pdata.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().InsertString("service.name", "frontend")
+	span := rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("foo")
+	span.SetStartTimestamp(pdata.Timestamp(10))
+	span.SetEndTimestamp(pdata.Timestamp(20))
+	span.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	span.SetSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	span.Attributes().InsertInt("http.status_code", 500)
+
+	marshaler := signozMarshaler{}
+	messages, err := marshaler.Marshal(td, "topic")
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "signoz", marshaler.Encoding())
+	assert.Equal(t, "topic", messages[0].Topic)
+	assert.Equal(t, sarama.ByteEncoder("0102030405060708090a0b0c0d0e0f10"), messages[0].Key)
+
+	value, err := messages[0].Value.Encode()
+	require.NoError(t, err)
+	row := &signoz.Span{}
+	require.NoError(t, easyjson.Unmarshal(value, row))
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", row.TraceId)
+	assert.Equal(t, "0102030405060708", row.SpanId)
+	assert.Equal(t, "foo", row.Name)
+	assert.Equal(t, "frontend", row.ServiceName)
+	assert.Equal(t, uint64(10), row.DurationNano)
+	assert.True(t, row.HasError)
+}
@@ -0,0 +1,235 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinotmetricsexporter
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+)
+
+// Config defines configuration for the Pinot metrics exporter.
+type Config struct {
+	config.ExporterSettings        `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
+
+	// The list of kafka brokers (default localhost:9092)
+	Brokers []string `mapstructure:"brokers"`
+	// Kafka protocol version
+	ProtocolVersion string `mapstructure:"protocol_version"`
+	// The name of the kafka topic Pinot ingests metric rows from (default signoz_metrics)
+	Topic string `mapstructure:"topic"`
+
+	// Metadata is the namespace for metadata management properties used by the
+	// Client, and shared by the Producer/Consumer.
+	Metadata kafkaexporter.Metadata `mapstructure:"metadata"`
+
+	// Producer is the namespaces for producer properties used only by the Producer
+	Producer kafkaexporter.Producer `mapstructure:"producer"`
+
+	// Authentication defines used authentication mechanism.
+	Authentication kafkaexporter.Authentication `mapstructure:"auth"`
+
+	// TopicValidation controls the check of the topic at start.
+	TopicValidation TopicValidation `mapstructure:"topic_validation"`
+
+	// Provisioning controls the creation of the Pinot schema and realtime table.
+	Provisioning Provisioning `mapstructure:"provisioning"`
+
+	// Debug controls the HTTP endpoint serving the state of the exporter.
+	Debug DebugSettings `mapstructure:"debug"`
+
+	// DryRun converts and encodes the metrics without connecting to Kafka or provisioning
+	// Pinot, and reports the rows and bytes that would have been produced.
+	DryRun bool `mapstructure:"dry_run"`
+}
+
+// DebugSettings defines the HTTP endpoint serving delivery stats, recent errors and
+// provisioning results as JSON.
+type DebugSettings struct {
+	// Enabled starts the endpoint.
+	Enabled bool `mapstructure:"enabled"`
+
+	// TCPAddr is the address the endpoint listens on (default localhost:55690). The endpoint
+	// has no authentication, keep it bound to localhost.
+	confignet.TCPAddr `mapstructure:",squash"`
+
+	// SamplePayloads is the number of encoded rows logged at debug level per minute and
+	// topic, to check what is written to Kafka. Nothing is logged when zero. It doesn't
+	// need the endpoint to be enabled.
+	SamplePayloads int `mapstructure:"sample_payloads"`
+}
+
+// TopicValidation defines how the exporter checks the topic when it starts.
+type TopicValidation struct {
+	// Enabled checks at start that the topic exists and has enough partitions.
+	Enabled bool `mapstructure:"enabled"`
+
+	// MinPartitions is the partition count the topic is expected to have at least (default 1).
+	MinPartitions int `mapstructure:"min_partitions"`
+
+	// FailOnError makes the exporter fail to start when the check fails, instead of logging an error.
+	FailOnError bool `mapstructure:"fail_on_error"`
+}
+
+// Provisioning defines how the exporter creates its schema and table on the Pinot controller.
+type Provisioning struct {
+	// Extension is the ID of the signozschema extension that creates the schema and
+	// table at start if they don't exist yet. Nothing is provisioned when unset.
+	Extension *config.ComponentID `mapstructure:"extension"`
+
+	// Table is the name of the schema and realtime table (default signoz_metrics).
+	Table string `mapstructure:"table"`
+
+	// Replication is the number of replicas per consuming segment (default 1).
+	Replication int `mapstructure:"replication"`
+
+	// RetentionDays is how long Pinot keeps segments before purging them (default 7).
+	RetentionDays int `mapstructure:"retention_days"`
+
+	// StreamBrokers is the broker list Pinot consumes from. Defaults to the exporter brokers,
+	// override it when Pinot reaches Kafka through a different address.
+	StreamBrokers []string `mapstructure:"stream_brokers"`
+
+	// Tasks are the minion task configs of the table keyed by task type, e.g. a PurgeTask
+	// removing the rows of a user on a right-to-be-forgotten request.
+	Tasks map[string]map[string]string `mapstructure:"tasks"`
+
+	// Stream tunes how Pinot consumes the topic into segments.
+	Stream StreamSettings `mapstructure:"stream"`
+
+	// Offline makes the table a hybrid one by also creating its offline table.
+	Offline OfflineSettings `mapstructure:"offline"`
+}
+
+// OfflineSettings defines the offline half of a hybrid table. It shares the schema of the
+// realtime table, and a RealtimeToOfflineSegmentsTask moves aged rows over to it.
+type OfflineSettings struct {
+	// Enabled creates the offline table and schedules the task moving rows to it.
+	Enabled bool `mapstructure:"enabled"`
+
+	// RetentionDays is how long the offline table keeps segments (default 30).
+	RetentionDays int `mapstructure:"retention_days"`
+
+	// BucketTimePeriod is the time range of rows moved by one task run (default 24h).
+	BucketTimePeriod time.Duration `mapstructure:"bucket_time_period"`
+
+	// BufferTimePeriod is the age rows must reach before they are moved (default 48h). It has
+	// to be shorter than the retention of the realtime table, or rows are purged first.
+	BufferTimePeriod time.Duration `mapstructure:"buffer_time_period"`
+}
+
+// StreamSettings defines the realtime consumption settings of the provisioned table.
+// Zero values leave the Pinot defaults in place.
+type StreamSettings struct {
+	// FlushThresholdRows is the number of rows after which a consuming segment is completed.
+	FlushThresholdRows int `mapstructure:"flush_threshold_rows"`
+
+	// FlushThresholdTime is the time after which a consuming segment is completed.
+	FlushThresholdTime time.Duration `mapstructure:"flush_threshold_time"`
+
+	// FlushThresholdSegmentSize is the desired size of completed segments, e.g. 200M. Pinot
+	// only sizes segments by it when FlushThresholdRows is zero.
+	FlushThresholdSegmentSize string `mapstructure:"flush_threshold_segment_size"`
+
+	// OffsetReset is where consumption starts when there is no committed offset, smallest or largest (default smallest).
+	OffsetReset string `mapstructure:"offset_reset"`
+
+	// ConsumerType is the Pinot consumer type, lowlevel or highlevel (default lowlevel).
+	ConsumerType string `mapstructure:"consumer_type"`
+}
+
+var _ config.Exporter = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if len(cfg.Brokers) == 0 {
+		return errors.New("brokers must not be empty")
+	}
+	if cfg.Topic == "" {
+		return errors.New("topic must not be empty")
+	}
+	if cfg.Producer.RequiredAcks < -1 || cfg.Producer.RequiredAcks > 1 {
+		return fmt.Errorf("producer.required_acks has to be between -1 and 1. configured value %v", cfg.Producer.RequiredAcks)
+	}
+	if cfg.TopicValidation.Enabled && cfg.TopicValidation.MinPartitions < 1 {
+		return fmt.Errorf("topic_validation.min_partitions must be at least 1. configured value %v", cfg.TopicValidation.MinPartitions)
+	}
+	if cfg.Debug.Enabled && cfg.Debug.Endpoint == "" {
+		return errors.New("debug.endpoint must not be empty")
+	}
+	if cfg.Debug.SamplePayloads < 0 {
+		return fmt.Errorf("debug.sample_payloads must not be negative. configured value %v", cfg.Debug.SamplePayloads)
+	}
+	if cfg.Provisioning.Extension == nil {
+		return nil
+	}
+	if cfg.Provisioning.Table == "" {
+		return errors.New("provisioning.table must not be empty")
+	}
+	if cfg.Provisioning.Replication < 1 {
+		return fmt.Errorf("provisioning.replication must be at least 1. configured value %v", cfg.Provisioning.Replication)
+	}
+	if cfg.Provisioning.RetentionDays < 1 {
+		return fmt.Errorf("provisioning.retention_days must be at least 1. configured value %v", cfg.Provisioning.RetentionDays)
+	}
+	if err := cfg.Provisioning.Stream.validate(); err != nil {
+		return err
+	}
+	return cfg.Provisioning.Offline.validate(cfg.Provisioning.RetentionDays)
+}
+
+func (s StreamSettings) validate() error {
+	if s.FlushThresholdRows < 0 {
+		return fmt.Errorf("provisioning.stream.flush_threshold_rows must not be negative. configured value %v", s.FlushThresholdRows)
+	}
+	if s.FlushThresholdTime < 0 {
+		return fmt.Errorf("provisioning.stream.flush_threshold_time must not be negative. configured value %v", s.FlushThresholdTime)
+	}
+	if s.OffsetReset != "smallest" && s.OffsetReset != "largest" {
+		return fmt.Errorf("provisioning.stream.offset_reset must be smallest or largest. configured value %q", s.OffsetReset)
+	}
+	if s.ConsumerType != "lowlevel" && s.ConsumerType != "highlevel" {
+		return fmt.Errorf("provisioning.stream.consumer_type must be lowlevel or highlevel. configured value %q", s.ConsumerType)
+	}
+	return nil
+}
+
+func (s OfflineSettings) validate(realtimeRetentionDays int) error {
+	if !s.Enabled {
+		return nil
+	}
+	if s.RetentionDays < 1 {
+		return fmt.Errorf("provisioning.offline.retention_days must be at least 1. configured value %v", s.RetentionDays)
+	}
+	if s.BucketTimePeriod <= 0 {
+		return fmt.Errorf("provisioning.offline.bucket_time_period must be positive. configured value %v", s.BucketTimePeriod)
+	}
+	if s.BufferTimePeriod <= 0 {
+		return fmt.Errorf("provisioning.offline.buffer_time_period must be positive. configured value %v", s.BufferTimePeriod)
+	}
+	if s.BufferTimePeriod >= time.Duration(realtimeRetentionDays)*24*time.Hour {
+		return fmt.Errorf("provisioning.offline.buffer_time_period must be shorter than provisioning.retention_days. configured value %v", s.BufferTimePeriod)
+	}
+	return nil
+}
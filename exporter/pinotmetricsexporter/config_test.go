@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinotmetricsexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/service/servicetest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(cfg.Exporters))
+
+	c := cfg.Exporters[config.NewComponentID(typeStr)].(*Config)
+	schemaID := config.NewComponentID("signozschema")
+	assert.Equal(t, &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		TimeoutSettings: exporterhelper.TimeoutSettings{
+			Timeout: 10 * time.Second,
+		},
+		RetrySettings: exporterhelper.DefaultRetrySettings(),
+		QueueSettings: exporterhelper.QueueSettings{
+			Enabled:      true,
+			NumConsumers: 2,
+			QueueSize:    10,
+		},
+		Topic:           "metrics",
+		Brokers:         []string{"foo:123", "bar:456"},
+		ProtocolVersion: "2.0.0",
+		Metadata: kafkaexporter.Metadata{
+			Full: true,
+			Retry: kafkaexporter.MetadataRetry{
+				Max:     defaultMetadataRetryMax,
+				Backoff: defaultMetadataRetryBackoff,
+			},
+		},
+		Producer: kafkaexporter.Producer{
+			MaxMessageBytes: 10000000,
+			RequiredAcks:    sarama.WaitForAll,
+		},
+		TopicValidation: TopicValidation{
+			Enabled:       true,
+			MinPartitions: 4,
+			FailOnError:   true,
+		},
+		Provisioning: Provisioning{
+			Extension:     &schemaID,
+			Table:         "metrics",
+			Replication:   2,
+			RetentionDays: 30,
+			StreamBrokers: []string{"kafka:9092"},
+			Tasks: map[string]map[string]string{
+				"PurgeTask": {"schedule": "0 0 * * * ?"},
+			},
+			Stream: StreamSettings{
+				FlushThresholdRows: 1000000,
+				FlushThresholdTime: 6 * time.Hour,
+				OffsetReset:        "largest",
+				ConsumerType:       "lowlevel",
+			},
+			Offline: OfflineSettings{
+				Enabled:          true,
+				RetentionDays:    365,
+				BucketTimePeriod: 24 * time.Hour,
+				BufferTimePeriod: 72 * time.Hour,
+			},
+		},
+		Debug: DebugSettings{
+			Enabled: true,
+			TCPAddr: confignet.TCPAddr{Endpoint: defaultDebugEndpoint},
+		},
+	}, c)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:   "default",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:    "no brokers",
+			modify:  func(cfg *Config) { cfg.Brokers = nil },
+			wantErr: "brokers must not be empty",
+		},
+		{
+			name:    "no topic",
+			modify:  func(cfg *Config) { cfg.Topic = "" },
+			wantErr: "topic must not be empty",
+		},
+		{
+			name:    "invalid acks",
+			modify:  func(cfg *Config) { cfg.Producer.RequiredAcks = 2 },
+			wantErr: "producer.required_acks has to be between -1 and 1. configured value 2",
+		},
+		{
+			name: "topic validation without partitions",
+			modify: func(cfg *Config) {
+				cfg.TopicValidation.Enabled = true
+				cfg.TopicValidation.MinPartitions = 0
+			},
+			wantErr: "topic_validation.min_partitions must be at least 1. configured value 0",
+		},
+		{
+			name: "debug without endpoint",
+			modify: func(cfg *Config) {
+				cfg.Debug.Enabled = true
+				cfg.Debug.Endpoint = ""
+			},
+			wantErr: "debug.endpoint must not be empty",
+		},
+		{
+			name:    "negative sample payloads",
+			modify:  func(cfg *Config) { cfg.Debug.SamplePayloads = -1 },
+			wantErr: "debug.sample_payloads must not be negative. configured value -1",
+		},
+		{
+			name: "provisioning without table",
+			modify: func(cfg *Config) {
+				id := config.NewComponentID("signozschema")
+				cfg.Provisioning.Extension = &id
+				cfg.Provisioning.Table = ""
+			},
+			wantErr: "provisioning.table must not be empty",
+		},
+		{
+			name: "provisioning without replication",
+			modify: func(cfg *Config) {
+				id := config.NewComponentID("signozschema")
+				cfg.Provisioning.Extension = &id
+				cfg.Provisioning.Replication = 0
+			},
+			wantErr: "provisioning.replication must be at least 1. configured value 0",
+		},
+		{
+			name: "provisioning with invalid offset reset",
+			modify: func(cfg *Config) {
+				id := config.NewComponentID("signozschema")
+				cfg.Provisioning.Extension = &id
+				cfg.Provisioning.Stream.OffsetReset = "earliest"
+			},
+			wantErr: `provisioning.stream.offset_reset must be smallest or largest. configured value "earliest"`,
+		},
+		{
+			name: "offline buffer beyond realtime retention",
+			modify: func(cfg *Config) {
+				id := config.NewComponentID("signozschema")
+				cfg.Provisioning.Extension = &id
+				cfg.Provisioning.Offline.Enabled = true
+				cfg.Provisioning.Offline.BufferTimePeriod = 7 * 24 * time.Hour
+			},
+			wantErr: "provisioning.offline.buffer_time_period must be shorter than provisioning.retention_days. configured value 168h0m0s",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinotmetricsexporter
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// maxRecentErrors is the number of errors kept for the debug endpoint.
+const maxRecentErrors = 20
+
+// exporterStatus collects what the debug endpoint shows about the exporter.
+// The zero value is ready to use.
+type exporterStatus struct {
+	mu              sync.Mutex
+	topics          map[string]*topicStats
+	inFlightBatches int
+	droppedMetrics  int64
+	recentErrors    []errorEntry
+	topicValidation *stepResult
+	provisioning    map[string]stepResult
+}
+
+type topicStats struct {
+	SentBatches   int64      `json:"sentBatches"`
+	SentRows      int64      `json:"sentRows"`
+	SentBytes     int64      `json:"sentBytes"`
+	FailedBatches int64      `json:"failedBatches"`
+	FailedRows    int64      `json:"failedRows"`
+	LastSuccess   *time.Time `json:"lastSuccess,omitempty"`
+	LastFailure   *time.Time `json:"lastFailure,omitempty"`
+	// The batches, rows and bytes that would have been sent on a dry run.
+	DryRunBatches int64 `json:"dryRunBatches,omitempty"`
+	DryRunRows    int64 `json:"dryRunRows,omitempty"`
+	DryRunBytes   int64 `json:"dryRunBytes,omitempty"`
+}
+
+type errorEntry struct {
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+}
+
+// stepResult is the outcome of a topic validation or of provisioning a table.
+type stepResult struct {
+	Name  string    `json:"name"`
+	Time  time.Time `json:"time"`
+	Error string    `json:"error,omitempty"`
+}
+
+type queueStatus struct {
+	Enabled         bool `json:"enabled"`
+	NumConsumers    int  `json:"numConsumers"`
+	QueueCapacity   int  `json:"queueCapacity"`
+	InFlightBatches int  `json:"inFlightBatches"`
+}
+
+// statusSnapshot is the JSON document served by the debug endpoint.
+type statusSnapshot struct {
+	DryRun          bool                  `json:"dryRun"`
+	Topics          map[string]topicStats `json:"topics"`
+	Queue           queueStatus           `json:"queue"`
+	DroppedMetrics  int64                 `json:"droppedMetrics"`
+	RecentErrors    []errorEntry          `json:"recentErrors"`
+	TopicValidation *stepResult           `json:"topicValidation,omitempty"`
+	Provisioning    []stepResult          `json:"provisioning"`
+}
+
+func (s *exporterStatus) batchStarted() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inFlightBatches++
+}
+
+// batchDone records the delivery of a batch of rows to the topic.
+func (s *exporterStatus) batchDone(topic string, rows, bytes int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inFlightBatches--
+	stats := s.topicLocked(topic)
+	now := time.Now()
+	if err != nil {
+		stats.FailedBatches++
+		stats.FailedRows += int64(rows)
+		stats.LastFailure = &now
+		s.addErrorLocked(now, err)
+		return
+	}
+	stats.SentBatches++
+	stats.SentRows += int64(rows)
+	stats.SentBytes += int64(bytes)
+	stats.LastSuccess = &now
+}
+
+// batchEncoded records a batch of rows that would have been sent to the topic on a dry run.
+func (s *exporterStatus) batchEncoded(topic string, rows, bytes int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := s.topicLocked(topic)
+	stats.DryRunBatches++
+	stats.DryRunRows += int64(rows)
+	stats.DryRunBytes += int64(bytes)
+}
+
+func (s *exporterStatus) topicLocked(topic string) *topicStats {
+	if s.topics == nil {
+		s.topics = make(map[string]*topicStats)
+	}
+	stats, ok := s.topics[topic]
+	if !ok {
+		stats = &topicStats{}
+		s.topics[topic] = stats
+	}
+	return stats
+}
+
+func (s *exporterStatus) metricsDropped(count int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.droppedMetrics += int64(count)
+}
+
+func (s *exporterStatus) topicValidated(topic string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.topicValidation = newStepResult(topic, err)
+	if err != nil {
+		s.addErrorLocked(s.topicValidation.Time, err)
+	}
+}
+
+// tableProvisioned records the last result of provisioning the table.
+func (s *exporterStatus) tableProvisioned(table string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.provisioning == nil {
+		s.provisioning = make(map[string]stepResult)
+	}
+	result := newStepResult(table, err)
+	s.provisioning[table] = *result
+	if err != nil {
+		s.addErrorLocked(result.Time, err)
+	}
+}
+
+func (s *exporterStatus) addErrorLocked(now time.Time, err error) {
+	s.recentErrors = append(s.recentErrors, errorEntry{Time: now, Message: err.Error()})
+	if len(s.recentErrors) > maxRecentErrors {
+		s.recentErrors = s.recentErrors[len(s.recentErrors)-maxRecentErrors:]
+	}
+}
+
+func (s *exporterStatus) snapshot(cfg *Config) statusSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snap := statusSnapshot{
+		DryRun: cfg.DryRun,
+		Topics: make(map[string]topicStats, len(s.topics)),
+		Queue: queueStatus{
+			Enabled:         cfg.QueueSettings.Enabled,
+			NumConsumers:    cfg.QueueSettings.NumConsumers,
+			QueueCapacity:   cfg.QueueSettings.QueueSize,
+			InFlightBatches: s.inFlightBatches,
+		},
+		DroppedMetrics: s.droppedMetrics,
+		RecentErrors:   append([]errorEntry{}, s.recentErrors...),
+		Provisioning:   make([]stepResult, 0, len(s.provisioning)),
+	}
+	for topic, stats := range s.topics {
+		snap.Topics[topic] = *stats
+	}
+	if s.topicValidation != nil {
+		result := *s.topicValidation
+		snap.TopicValidation = &result
+	}
+	for _, result := range s.provisioning {
+		snap.Provisioning = append(snap.Provisioning, result)
+	}
+	sort.Slice(snap.Provisioning, func(i, j int) bool {
+		return snap.Provisioning[i].Name < snap.Provisioning[j].Name
+	})
+	return snap
+}
+
+func newStepResult(name string, err error) *stepResult {
+	result := &stepResult{Name: name, Time: time.Now()}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// payloadSampler lets through a limited number of payloads per minute and topic.
+// The zero value lets none through.
+type payloadSampler struct {
+	mu      sync.Mutex
+	limit   int
+	windows map[string]*sampleWindow
+}
+
+type sampleWindow struct {
+	start time.Time
+	count int
+}
+
+// allow reports whether a payload produced to the topic at now should be logged.
+func (s *payloadSampler) allow(topic string, now time.Time) bool {
+	if s.limit <= 0 {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.windows == nil {
+		s.windows = make(map[string]*sampleWindow)
+	}
+	w, ok := s.windows[topic]
+	if !ok || now.Sub(w.start) >= time.Minute {
+		w = &sampleWindow{start: now}
+		s.windows[topic] = w
+	}
+	if w.count >= s.limit {
+		return false
+	}
+	w.count++
+	return true
+}
+
+// startDebugServer serves the status of the exporter as JSON on the configured endpoint.
+func (e *pinotMetricsExporter) startDebugServer(host component.Host) error {
+	ln, err := e.config.Debug.Listen()
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(e.status.snapshot(e.config))
+	})
+	e.debugServer = &http.Server{Handler: mux}
+	e.debugStopCh = make(chan struct{})
+	go func() {
+		defer close(e.debugStopCh)
+
+		// The listener ownership goes to the server.
+		if err := e.debugServer.Serve(ln); !errors.Is(err, http.ErrServerClosed) && err != nil {
+			host.ReportFatalError(err)
+		}
+	}()
+	return nil
+}
+
+func (e *pinotMetricsExporter) shutdownDebugServer() error {
+	if e.debugServer == nil {
+		return nil
+	}
+	err := e.debugServer.Close()
+	<-e.debugStopCh
+	return err
+}
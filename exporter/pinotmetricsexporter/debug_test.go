@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinotmetricsexporter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+type failingSchemaManager struct {
+	component.Extension
+}
+
+func (failingSchemaManager) EnsureTable(_ context.Context, name string, _, _ interface{}) error {
+	return fmt.Errorf("failed to create table %s", name)
+}
+
+func TestDebugEndpoint(t *testing.T) {
+	id := config.NewComponentID("signozschema")
+	host := extensionHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{id: failingSchemaManager{}},
+	}
+
+	producer := mocks.NewSyncProducer(t, sarama.NewConfig())
+	for i := 0; i < 3; i++ {
+		producer.ExpectSendMessageAndSucceed()
+	}
+	producer.ExpectSendMessageAndFail(errors.New("failed to send"))
+	producer.ExpectSendMessageAndSucceed()
+	producer.ExpectSendMessageAndSucceed()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Debug.Enabled = true
+	cfg.Debug.Endpoint = testutil.GetAvailableLocalAddress(t)
+	cfg.Provisioning.Extension = &id
+	exp := &pinotMetricsExporter{config: cfg, producer: producer, logger: zap.NewNop()}
+	t.Cleanup(func() {
+		require.NoError(t, exp.Shutdown(context.Background()))
+	})
+
+	// The endpoint isn't left bound when starting fails.
+	require.EqualError(t, exp.Start(context.Background(), host), "failed to create table signoz_metrics")
+	_, err := http.Get("http://" + cfg.Debug.Endpoint)
+	require.Error(t, err)
+
+	// It shows the provisioning failure once started.
+	cfg.Provisioning.Extension = nil
+	require.NoError(t, exp.Start(context.Background(), host))
+	require.NoError(t, exp.pushMetrics(context.Background(), testMetrics(time.Now())))
+	require.Error(t, exp.pushMetrics(context.Background(), testMetrics(time.Now())))
+
+	resp, err := http.Get("http://" + cfg.Debug.Endpoint)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	var snap statusSnapshot
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&snap))
+
+	stats := snap.Topics[defaultTopic]
+	assert.EqualValues(t, 1, stats.SentBatches)
+	assert.EqualValues(t, 3, stats.SentRows)
+	assert.Positive(t, stats.SentBytes)
+	assert.EqualValues(t, 1, stats.FailedBatches)
+	assert.EqualValues(t, 3, stats.FailedRows)
+	assert.NotNil(t, stats.LastSuccess)
+	assert.NotNil(t, stats.LastFailure)
+	assert.Equal(t, 0, snap.Queue.InFlightBatches)
+	assert.True(t, snap.Queue.Enabled)
+	assert.Equal(t, cfg.QueueSettings.QueueSize, snap.Queue.QueueCapacity)
+
+	require.Len(t, snap.Provisioning, 1)
+	assert.Equal(t, "signoz_metrics", snap.Provisioning[0].Name)
+	assert.Equal(t, "failed to create table signoz_metrics", snap.Provisioning[0].Error)
+
+	require.Len(t, snap.RecentErrors, 2)
+	assert.Equal(t, "failed to create table signoz_metrics", snap.RecentErrors[0].Message)
+	assert.Equal(t, "failed to send", snap.RecentErrors[1].Message)
+}
+
+func TestRecentErrorsLimit(t *testing.T) {
+	var status exporterStatus
+	for i := 0; i < maxRecentErrors+5; i++ {
+		status.batchStarted()
+		status.batchDone(defaultTopic, 1, 0, fmt.Errorf("error %d", i))
+	}
+
+	snap := status.snapshot(createDefaultConfig().(*Config))
+	require.Len(t, snap.RecentErrors, maxRecentErrors)
+	assert.Equal(t, "error 5", snap.RecentErrors[0].Message)
+	assert.EqualValues(t, maxRecentErrors+5, snap.Topics[defaultTopic].FailedBatches)
+}
+
+func TestPayloadSampler(t *testing.T) {
+	now := time.Now()
+	s := &payloadSampler{limit: 2}
+	assert.True(t, s.allow("signoz_metrics", now))
+	assert.True(t, s.allow("signoz_metrics", now.Add(time.Second)))
+	assert.False(t, s.allow("signoz_metrics", now.Add(2*time.Second)))
+	// Every topic has its own budget.
+	assert.True(t, s.allow("signoz_metrics_errors", now.Add(2*time.Second)))
+	// The budget is renewed every minute.
+	assert.True(t, s.allow("signoz_metrics", now.Add(time.Minute)))
+
+	assert.False(t, (&payloadSampler{}).allow("signoz_metrics", now))
+}
@@ -0,0 +1,286 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinotmetricsexporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/signozschemaextension"
+)
+
+// pinotMetricsExporter writes flattened metric rows to the Kafka topic a Pinot realtime table consumes.
+type pinotMetricsExporter struct {
+	config   *Config
+	producer sarama.SyncProducer
+	logger   *zap.Logger
+
+	status      exporterStatus
+	sampler     payloadSampler
+	debugServer *http.Server
+	debugStopCh chan struct{}
+}
+
+func newExporter(cfg *Config, set component.ExporterCreateSettings) (*pinotMetricsExporter, error) {
+	e := &pinotMetricsExporter{
+		config:  cfg,
+		logger:  set.Logger,
+		sampler: payloadSampler{limit: cfg.Debug.SamplePayloads},
+	}
+	if cfg.DryRun {
+		e.logger.Warn("Dry run, metric rows are not produced to Kafka", zap.String("topic", cfg.Topic))
+		return e, nil
+	}
+	producer, err := newSaramaProducer(cfg)
+	if err != nil {
+		return nil, err
+	}
+	e.producer = producer
+	return e, nil
+}
+
+func newSaramaProducer(cfg *Config) (sarama.SyncProducer, error) {
+	c, err := newSaramaConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return sarama.NewSyncProducer(cfg.Brokers, c)
+}
+
+func newSaramaConfig(cfg *Config) (*sarama.Config, error) {
+	c := sarama.NewConfig()
+	// These setting are required by the sarama.SyncProducer implementation.
+	c.Producer.Return.Successes = true
+	c.Producer.Return.Errors = true
+	c.Producer.RequiredAcks = cfg.Producer.RequiredAcks
+	// Because sarama does not accept a Context for every message, set the Timeout here.
+	c.Producer.Timeout = cfg.Timeout
+	c.Metadata.Full = cfg.Metadata.Full
+	c.Metadata.Retry.Max = cfg.Metadata.Retry.Max
+	c.Metadata.Retry.Backoff = cfg.Metadata.Retry.Backoff
+	c.Producer.MaxMessageBytes = cfg.Producer.MaxMessageBytes
+	// Sarama retries failed partitions internally, the backoff function is the only place they show up.
+	backoff := c.Producer.Retry.Backoff
+	c.Producer.Retry.BackoffFunc = func(int, int) time.Duration {
+		recordRetry(cfg.Topic)
+		return backoff
+	}
+	if cfg.ProtocolVersion != "" {
+		version, err := sarama.ParseKafkaVersion(cfg.ProtocolVersion)
+		if err != nil {
+			return nil, err
+		}
+		c.Version = version
+	}
+	if err := kafkaexporter.ConfigureAuthentication(cfg.Authentication, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Start validates the topic and provisions the Pinot schema and table through the
+// configured signozschema extension, when enabled. Neither Kafka nor Pinot is touched
+// on a dry run. The debug endpoint is started last, so it isn't left bound when
+// starting fails; the results of the earlier steps are kept for it.
+func (e *pinotMetricsExporter) Start(ctx context.Context, host component.Host) error {
+	if !e.config.DryRun {
+		if err := e.checkTopic(); err != nil {
+			return err
+		}
+		if err := e.provision(ctx, host); err != nil {
+			return err
+		}
+	}
+	if e.config.Debug.Enabled {
+		return e.startDebugServer(host)
+	}
+	return nil
+}
+
+// checkTopic validates the topic, when enabled, and only returns the failure when
+// it is configured to fail the start.
+func (e *pinotMetricsExporter) checkTopic() error {
+	if !e.config.TopicValidation.Enabled {
+		return nil
+	}
+	err := e.validateTopic()
+	e.status.topicValidated(e.config.Topic, err)
+	if err == nil {
+		return nil
+	}
+	if e.config.TopicValidation.FailOnError {
+		return err
+	}
+	e.logger.Error("Topic validation failed, Pinot will not receive metric rows until it is fixed",
+		zap.String("topic", e.config.Topic), zap.Error(err))
+	return nil
+}
+
+// provision creates the Pinot schema and tables through the signozschema extension.
+func (e *pinotMetricsExporter) provision(ctx context.Context, host component.Host) error {
+	if e.config.Provisioning.Extension == nil {
+		return nil
+	}
+	sm, err := signozschemaextension.GetSchemaManager(host.GetExtensions(), *e.config.Provisioning.Extension)
+	if err != nil {
+		return err
+	}
+	table := e.config.Provisioning.Table
+	schema := newMetricsSchema(table)
+	err = sm.EnsureTable(ctx, table, schema, newMetricsTableConfig(e.config))
+	e.status.tableProvisioned(table, err)
+	if err != nil {
+		return err
+	}
+	if !e.config.Provisioning.Offline.Enabled {
+		return nil
+	}
+	// Both halves of the hybrid table use the same schema, which already exists by now.
+	err = sm.EnsureTable(ctx, table+"_OFFLINE", schema, newMetricsOfflineTableConfig(e.config))
+	e.status.tableProvisioned(table+"_OFFLINE", err)
+	return err
+}
+
+// validateTopic checks that the topic exists and has at least the configured number of partitions.
+func (e *pinotMetricsExporter) validateTopic() error {
+	c, err := newSaramaConfig(e.config)
+	if err != nil {
+		return err
+	}
+	// Brokers with auto.create.topics.enable would create a misspelled topic
+	// while it is being validated.
+	c.Metadata.AllowAutoTopicCreation = false
+	client, err := sarama.NewClient(e.config.Brokers, c)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	partitions, err := client.Partitions(e.config.Topic)
+	if err != nil {
+		return fmt.Errorf("failed to get partitions of topic %s: %w", e.config.Topic, err)
+	}
+	if len(partitions) < e.config.TopicValidation.MinPartitions {
+		return fmt.Errorf("topic %s has %d partitions, expected at least %d", e.config.Topic, len(partitions), e.config.TopicValidation.MinPartitions)
+	}
+	return nil
+}
+
+func (e *pinotMetricsExporter) pushMetrics(_ context.Context, md pdata.Metrics) error {
+	rows, dropped := metricsToRows(md)
+	if dropped > 0 {
+		e.logger.Debug("Dropped metrics of unsupported types", zap.Int("count", dropped))
+		e.status.metricsDropped(dropped)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	messages, size, encodeErr := e.encodeRows(rows)
+	if encodeErr != nil {
+		// Retrying can't make these rows fit or encodable.
+		encodeErr = consumererror.NewPermanent(encodeErr)
+	}
+	if len(messages) == 0 {
+		return encodeErr
+	}
+
+	if e.config.DryRun {
+		e.logger.Info("Dry run, metric rows not produced",
+			zap.String("topic", e.config.Topic), zap.Int("rows", len(messages)), zap.Int("bytes", size))
+		e.status.batchEncoded(e.config.Topic, len(messages), size)
+		return encodeErr
+	}
+
+	e.status.batchStarted()
+	err := e.sendMessages(messages, size)
+	e.status.batchDone(e.config.Topic, len(messages), size, err)
+	return multierr.Append(err, encodeErr)
+}
+
+// encodeRows encodes the rows into messages for the topic and returns the messages and
+// their size in bytes. Rows that can't be encoded, such as the ones holding NaN or
+// infinite values JSON has no representation for, and rows larger than
+// producer.max_message_bytes are left out without failing the rest, and returned as an
+// error naming each of them.
+func (e *pinotMetricsExporter) encodeRows(rows []*MetricRow) ([]*sarama.ProducerMessage, int, error) {
+	messages := make([]*sarama.ProducerMessage, 0, len(rows))
+	size := 0
+	var errs error
+	for _, row := range rows {
+		value, err := json.Marshal(row)
+		if err != nil {
+			e.status.metricsDropped(1)
+			errs = multierr.Append(errs, fmt.Errorf("failed to encode row of metric %s with fingerprint %d: %w", row.MetricName, row.Fingerprint, err))
+			continue
+		}
+		if maxBytes := e.config.Producer.MaxMessageBytes; maxBytes > 0 && len(value) > maxBytes {
+			e.status.metricsDropped(1)
+			errs = multierr.Append(errs, fmt.Errorf("row of metric %s with fingerprint %d is %d bytes, more than producer.max_message_bytes %d", row.MetricName, row.Fingerprint, len(value), maxBytes))
+			continue
+		}
+		if e.sampler.allow(e.config.Topic, time.Now()) {
+			e.logger.Debug("Sample metric row", zap.String("topic", e.config.Topic), zap.ByteString("payload", value))
+		}
+		size += len(value)
+		messages = append(messages, &sarama.ProducerMessage{
+			Topic: e.config.Topic,
+			Value: sarama.ByteEncoder(value),
+		})
+	}
+	return messages, size, errs
+}
+
+// sendMessages produces the messages of the given size in bytes to the topic.
+func (e *pinotMetricsExporter) sendMessages(messages []*sarama.ProducerMessage, size int) error {
+	err := e.producer.SendMessages(messages)
+	if err == nil {
+		recordProduced(e.config.Topic, len(messages), size, 0)
+		return nil
+	}
+	value, ok := err.(sarama.ProducerErrors)
+	if !ok || len(value) == 0 {
+		recordProduced(e.config.Topic, 0, 0, len(messages))
+		return err
+	}
+	failedBytes := 0
+	for _, pe := range value {
+		if pe.Msg != nil && pe.Msg.Value != nil {
+			failedBytes += pe.Msg.Value.Length()
+		}
+	}
+	recordProduced(e.config.Topic, len(messages)-len(value), size-failedBytes, len(value))
+	return fmt.Errorf("failed to deliver %d metric rows: %w", len(value), value[0].Err)
+}
+
+// Shutdown stops the debug endpoint and closes the producer.
+func (e *pinotMetricsExporter) Shutdown(context.Context) error {
+	err := e.shutdownDebugServer()
+	if e.producer == nil {
+		return err
+	}
+	return multierr.Append(err, e.producer.Close())
+}
@@ -0,0 +1,342 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinotmetricsexporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/signozschemaextension"
+)
+
+func TestPushMetrics(t *testing.T) {
+	c := sarama.NewConfig()
+	producer := mocks.NewSyncProducer(t, c)
+	for i := 0; i < 3; i++ {
+		producer.ExpectSendMessageWithCheckerFunctionAndSucceed(func(value []byte) error {
+			var row MetricRow
+			if err := json.Unmarshal(value, &row); err != nil {
+				return err
+			}
+			if row.ServiceName != "frontend" {
+				return fmt.Errorf("unexpected service name %q", row.ServiceName)
+			}
+			return nil
+		})
+	}
+
+	exp := &pinotMetricsExporter{
+		config:   createDefaultConfig().(*Config),
+		producer: producer,
+		logger:   zap.NewNop(),
+	}
+	t.Cleanup(func() {
+		require.NoError(t, exp.Shutdown(context.Background()))
+	})
+	require.NoError(t, exp.pushMetrics(context.Background(), testMetrics(time.Now())))
+}
+
+func TestPushMetricsSamplePayloads(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, sarama.NewConfig())
+	for i := 0; i < 3; i++ {
+		producer.ExpectSendMessageAndSucceed()
+	}
+
+	core, logs := observer.New(zap.DebugLevel)
+	exp := &pinotMetricsExporter{
+		config:   createDefaultConfig().(*Config),
+		producer: producer,
+		logger:   zap.New(core),
+		sampler:  payloadSampler{limit: 2},
+	}
+	t.Cleanup(func() {
+		require.NoError(t, exp.Shutdown(context.Background()))
+	})
+	require.NoError(t, exp.pushMetrics(context.Background(), testMetrics(time.Now())))
+
+	samples := logs.FilterMessage("Sample metric row").All()
+	require.Len(t, samples, 2)
+	fields := samples[0].ContextMap()
+	assert.Equal(t, defaultTopic, fields["topic"])
+	var row MetricRow
+	require.NoError(t, json.Unmarshal([]byte(fields["payload"].(string)), &row))
+	assert.Equal(t, "frontend", row.ServiceName)
+}
+
+func TestPushMetrics_err(t *testing.T) {
+	c := sarama.NewConfig()
+	producer := mocks.NewSyncProducer(t, c)
+	producer.ExpectSendMessageAndFail(fmt.Errorf("failed to send"))
+	producer.ExpectSendMessageAndSucceed()
+	producer.ExpectSendMessageAndSucceed()
+
+	exp := &pinotMetricsExporter{
+		config:   createDefaultConfig().(*Config),
+		producer: producer,
+		logger:   zap.NewNop(),
+	}
+	t.Cleanup(func() {
+		require.NoError(t, exp.Shutdown(context.Background()))
+	})
+	err := exp.pushMetrics(context.Background(), testMetrics(time.Now()))
+	assert.EqualError(t, err, "failed to send")
+}
+
+func TestPushMetricsNonFinite(t *testing.T) {
+	c := sarama.NewConfig()
+	producer := mocks.NewSyncProducer(t, c)
+	producer.ExpectSendMessageWithCheckerFunctionAndSucceed(func(value []byte) error {
+		var row MetricRow
+		if err := json.Unmarshal(value, &row); err != nil {
+			return err
+		}
+		if row.MetricName != "queue.size" {
+			return fmt.Errorf("unexpected metric %q", row.MetricName)
+		}
+		return nil
+	})
+
+	md := pdata.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	for name, value := range map[string]float64{"queue.size": 42, "cpu.utilization": math.NaN(), "memory.limit": math.Inf(1)} {
+		gauge := metrics.AppendEmpty()
+		gauge.SetName(name)
+		gauge.SetDataType(pdata.MetricDataTypeGauge)
+		gauge.Gauge().DataPoints().AppendEmpty().SetDoubleVal(value)
+	}
+
+	exp := &pinotMetricsExporter{
+		config:   createDefaultConfig().(*Config),
+		producer: producer,
+		logger:   zap.NewNop(),
+	}
+	t.Cleanup(func() {
+		require.NoError(t, exp.Shutdown(context.Background()))
+	})
+	err := exp.pushMetrics(context.Background(), md)
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Contains(t, err.Error(), "cpu.utilization")
+	assert.Contains(t, err.Error(), "memory.limit")
+
+	snapshot := exp.status.snapshot(exp.config)
+	assert.Equal(t, int64(2), snapshot.DroppedMetrics)
+	assert.Equal(t, int64(1), snapshot.Topics[defaultTopic].SentRows)
+}
+
+func TestPushMetricsMaxMessageBytes(t *testing.T) {
+	c := sarama.NewConfig()
+	producer := mocks.NewSyncProducer(t, c)
+	producer.ExpectSendMessageWithCheckerFunctionAndSucceed(func(value []byte) error {
+		var row MetricRow
+		if err := json.Unmarshal(value, &row); err != nil {
+			return err
+		}
+		if row.MetricName != "queue.size" {
+			return fmt.Errorf("unexpected metric %q", row.MetricName)
+		}
+		return nil
+	})
+
+	md := pdata.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("queue.size")
+	gauge.SetDataType(pdata.MetricDataTypeGauge)
+	gauge.Gauge().DataPoints().AppendEmpty().SetDoubleVal(42)
+	large := metrics.AppendEmpty()
+	large.SetName("http.server.duration")
+	large.SetDataType(pdata.MetricDataTypeGauge)
+	dp := large.Gauge().DataPoints().AppendEmpty()
+	dp.SetDoubleVal(1)
+	dp.Attributes().InsertString("http.url", strings.Repeat("a", 500))
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Producer.MaxMessageBytes = 400
+	exp := &pinotMetricsExporter{
+		config:   cfg,
+		producer: producer,
+		logger:   zap.NewNop(),
+	}
+	t.Cleanup(func() {
+		require.NoError(t, exp.Shutdown(context.Background()))
+	})
+	err := exp.pushMetrics(context.Background(), md)
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Contains(t, err.Error(), "row of metric http.server.duration")
+	assert.Contains(t, err.Error(), "more than producer.max_message_bytes 400")
+
+	snapshot := exp.status.snapshot(exp.config)
+	assert.Equal(t, int64(1), snapshot.DroppedMetrics)
+	assert.Equal(t, int64(1), snapshot.Topics[defaultTopic].SentRows)
+}
+
+type fakeSchemaManager struct {
+	component.Extension
+	tables []string
+}
+
+var _ signozschemaextension.SchemaManager = (*fakeSchemaManager)(nil)
+
+func (m *fakeSchemaManager) EnsureTable(_ context.Context, name string, _, _ interface{}) error {
+	m.tables = append(m.tables, name)
+	return nil
+}
+
+type extensionHost struct {
+	component.Host
+	extensions map[config.ComponentID]component.Extension
+}
+
+func (h extensionHost) GetExtensions() map[config.ComponentID]component.Extension {
+	return h.extensions
+}
+
+func TestDryRun(t *testing.T) {
+	id := config.NewComponentID("signozschema")
+	sm := &fakeSchemaManager{}
+	host := extensionHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{id: sm},
+	}
+
+	cfg := createDefaultConfig().(*Config)
+	// Nothing listens on the brokers, a dry run must not connect to them.
+	cfg.Brokers = []string{"localhost:1"}
+	cfg.TopicValidation.Enabled = true
+	cfg.TopicValidation.FailOnError = true
+	cfg.Provisioning.Extension = &id
+	cfg.DryRun = true
+	exp, err := newExporter(cfg, componenttest.NewNopExporterCreateSettings())
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), host))
+	assert.Empty(t, sm.tables)
+
+	require.NoError(t, exp.pushMetrics(context.Background(), testMetrics(time.Now())))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	snapshot := exp.status.snapshot(cfg)
+	assert.True(t, snapshot.DryRun)
+	stats := snapshot.Topics[defaultTopic]
+	assert.Equal(t, int64(1), stats.DryRunBatches)
+	assert.Equal(t, int64(3), stats.DryRunRows)
+	assert.Greater(t, stats.DryRunBytes, int64(0))
+	assert.Zero(t, stats.SentRows)
+}
+
+func TestStart(t *testing.T) {
+	id := config.NewComponentID("signozschema")
+	sm := &fakeSchemaManager{}
+	host := extensionHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[config.ComponentID]component.Extension{id: sm},
+	}
+
+	cfg := createDefaultConfig().(*Config)
+	exp := &pinotMetricsExporter{config: cfg, logger: zap.NewNop()}
+	require.NoError(t, exp.Start(context.Background(), host))
+	assert.Empty(t, sm.tables)
+
+	cfg.Provisioning.Extension = &id
+	require.NoError(t, exp.Start(context.Background(), host))
+	assert.Equal(t, []string{"signoz_metrics"}, sm.tables)
+
+	sm.tables = nil
+	cfg.Provisioning.Offline.Enabled = true
+	require.NoError(t, exp.Start(context.Background(), host))
+	assert.Equal(t, []string{"signoz_metrics", "signoz_metrics_OFFLINE"}, sm.tables)
+
+	missing := config.NewComponentIDWithName("signozschema", "missing")
+	cfg.Provisioning.Extension = &missing
+	assert.Error(t, exp.Start(context.Background(), host))
+}
+
+func TestValidateTopic(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader(defaultTopic, 0, broker.BrokerID()).
+			SetLeader(defaultTopic, 1, broker.BrokerID()),
+	})
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Brokers = []string{broker.Addr()}
+	cfg.Metadata.Retry.Max = 0
+	cfg.TopicValidation.Enabled = true
+	cfg.TopicValidation.FailOnError = true
+	exp := &pinotMetricsExporter{config: cfg, logger: zap.NewNop()}
+
+	cfg.TopicValidation.MinPartitions = 2
+	assert.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	cfg.TopicValidation.MinPartitions = 3
+	assert.EqualError(t, exp.Start(context.Background(), componenttest.NewNopHost()),
+		"topic signoz_metrics has 2 partitions, expected at least 3")
+
+}
+
+func TestValidateTopicMissing(t *testing.T) {
+	broker := sarama.NewMockBroker(t, 1)
+	defer broker.Close()
+	broker.SetHandlerByMap(map[string]sarama.MockResponse{
+		"MetadataRequest": sarama.NewMockMetadataResponse(t).
+			SetBroker(broker.Addr(), broker.BrokerID()).
+			SetLeader(defaultTopic, 0, broker.BrokerID()),
+	})
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Brokers = []string{broker.Addr()}
+	cfg.Topic = "signoz_metrcs"
+	cfg.Metadata.Retry.Max = 0
+	cfg.TopicValidation.Enabled = true
+	cfg.TopicValidation.FailOnError = true
+	exp := &pinotMetricsExporter{config: cfg, logger: zap.NewNop()}
+
+	err := exp.Start(context.Background(), componenttest.NewNopHost())
+	assert.ErrorIs(t, err, sarama.ErrUnknownTopicOrPartition)
+
+	// The validation must not create the topic it is looking for.
+	var requests int
+	for _, rr := range broker.History() {
+		if req, ok := rr.Request.(*sarama.MetadataRequest); ok {
+			requests++
+			assert.False(t, req.AllowAutoTopicCreation)
+		}
+	}
+	assert.NotZero(t, requests)
+
+	cfg.TopicValidation.FailOnError = false
+	assert.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinotmetricsexporter
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr       = "pinotmetrics"
+	defaultTopic  = "signoz_metrics"
+	defaultTable  = "signoz_metrics"
+	defaultBroker = "localhost:9092"
+	// default from sarama.NewConfig()
+	defaultMetadataRetryMax = 3
+	// default from sarama.NewConfig()
+	defaultMetadataRetryBackoff = time.Millisecond * 250
+	// default max.message.bytes for the producer
+	defaultProducerMaxMessageBytes = 1000000
+	defaultMinPartitions           = 1
+	defaultReplication             = 1
+	defaultRetentionDays           = 7
+	defaultOfflineRetentionDays    = 30
+	defaultBucketTimePeriod        = 24 * time.Hour
+	defaultBufferTimePeriod        = 48 * time.Hour
+	defaultDebugEndpoint           = "localhost:55690"
+)
+
+// NewFactory creates a factory for the Pinot metrics exporter.
+func NewFactory() component.ExporterFactory {
+	view.Register(MetricViews()...)
+
+	return exporterhelper.NewFactory(
+		typeStr,
+		createDefaultConfig,
+		exporterhelper.WithMetrics(createMetricsExporter))
+}
+
+func createDefaultConfig() config.Exporter {
+	return &Config{
+		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		TimeoutSettings:  exporterhelper.DefaultTimeoutSettings(),
+		RetrySettings:    exporterhelper.DefaultRetrySettings(),
+		QueueSettings:    exporterhelper.DefaultQueueSettings(),
+		Brokers:          []string{defaultBroker},
+		Topic:            defaultTopic,
+		Metadata: kafkaexporter.Metadata{
+			Full: true,
+			Retry: kafkaexporter.MetadataRetry{
+				Max:     defaultMetadataRetryMax,
+				Backoff: defaultMetadataRetryBackoff,
+			},
+		},
+		Producer: kafkaexporter.Producer{
+			MaxMessageBytes: defaultProducerMaxMessageBytes,
+			RequiredAcks:    sarama.WaitForLocal,
+		},
+		TopicValidation: TopicValidation{
+			MinPartitions: defaultMinPartitions,
+		},
+		Provisioning: Provisioning{
+			Table:         defaultTable,
+			Replication:   defaultReplication,
+			RetentionDays: defaultRetentionDays,
+			Stream: StreamSettings{
+				OffsetReset:  "smallest",
+				ConsumerType: "lowlevel",
+			},
+			Offline: OfflineSettings{
+				RetentionDays:    defaultOfflineRetentionDays,
+				BucketTimePeriod: defaultBucketTimePeriod,
+				BufferTimePeriod: defaultBufferTimePeriod,
+			},
+		},
+		Debug: DebugSettings{
+			TCPAddr: confignet.TCPAddr{Endpoint: defaultDebugEndpoint},
+		},
+	}
+}
+
+func createMetricsExporter(_ context.Context, set component.ExporterCreateSettings,
+	cfg config.Exporter) (component.MetricsExporter, error) {
+
+	pCfg, ok := cfg.(*Config)
+	if !ok {
+		return nil, errors.New("invalid configuration")
+	}
+
+	exp, err := newExporter(pCfg, set)
+	if err != nil {
+		return nil, err
+	}
+
+	return exporterhelper.NewMetricsExporter(
+		cfg,
+		set,
+		exp.pushMetrics,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		// Disable exporterhelper Timeout, because we cannot pass a Context to the Producer,
+		// and will rely on the sarama Producer Timeout logic.
+		exporterhelper.WithTimeout(exporterhelper.TimeoutSettings{Timeout: 0}),
+		exporterhelper.WithRetry(pCfg.RetrySettings),
+		exporterhelper.WithQueue(pCfg.QueueSettings),
+		exporterhelper.WithStart(exp.Start),
+		exporterhelper.WithShutdown(exp.Shutdown))
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinotmetricsexporter
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	mProducedMessages = stats.Int64("pinotmetrics_produced_messages", "Number of metric rows produced to a topic", stats.UnitDimensionless)
+	mProducedBytes    = stats.Int64("pinotmetrics_produced_bytes", "Size of the metric rows produced to a topic", stats.UnitBytes)
+	mRetries          = stats.Int64("pinotmetrics_retries", "Number of times the producer retried sending to a partition of a topic", stats.UnitDimensionless)
+	mFailedMessages   = stats.Int64("pinotmetrics_failed_messages", "Number of metric rows that failed to be produced to a topic", stats.UnitDimensionless)
+
+	topicKey = tag.MustNewKey("topic")
+)
+
+// MetricViews returns the views of the exporter's self metrics. They are broken down
+// by topic, as totals hide a single topic failing.
+func MetricViews() []*view.View {
+	measures := []*stats.Int64Measure{mProducedMessages, mProducedBytes, mRetries, mFailedMessages}
+	views := make([]*view.View, 0, len(measures))
+	for _, m := range measures {
+		views = append(views, &view.View{
+			Name:        m.Name(),
+			Measure:     m,
+			Description: m.Description(),
+			TagKeys:     []tag.Key{topicKey},
+			Aggregation: view.Sum(),
+		})
+	}
+	return views
+}
+
+// recordProduced records the rows and bytes delivered to the topic and the rows that failed.
+func recordProduced(topic string, messages, bytes, failed int) {
+	ctx, _ := tag.New(context.Background(), tag.Upsert(topicKey, topic))
+	stats.Record(ctx, mProducedMessages.M(int64(messages)), mProducedBytes.M(int64(bytes)), mFailedMessages.M(int64(failed)))
+}
+
+func recordRetry(topic string) {
+	ctx, _ := tag.New(context.Background(), tag.Upsert(topicKey, topic))
+	stats.Record(ctx, mRetries.M(1))
+}
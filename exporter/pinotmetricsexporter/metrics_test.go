@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinotmetricsexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+)
+
+func TestMetricViews(t *testing.T) {
+	expectedViewNames := []string{
+		"pinotmetrics_produced_messages",
+		"pinotmetrics_produced_bytes",
+		"pinotmetrics_retries",
+		"pinotmetrics_failed_messages",
+	}
+
+	views := MetricViews()
+	require.Len(t, views, len(expectedViewNames))
+	for i, viewName := range expectedViewNames {
+		assert.Equal(t, viewName, views[i].Name)
+	}
+}
+
+func TestRecordProduced(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	recordProduced("pinot_rows", 3, 300, 0)
+	recordProduced("pinot_errors", 1, 100, 2)
+	recordRetry("pinot_errors")
+
+	for name, expected := range map[string]map[string]float64{
+		"pinotmetrics_produced_messages": {"pinot_rows": 3, "pinot_errors": 1},
+		"pinotmetrics_produced_bytes":    {"pinot_rows": 300, "pinot_errors": 100},
+		"pinotmetrics_failed_messages":   {"pinot_rows": 0, "pinot_errors": 2},
+		"pinotmetrics_retries":           {"pinot_errors": 1},
+	} {
+		rows, err := view.RetrieveData(name)
+		require.NoError(t, err)
+		seen := 0
+		for _, row := range rows {
+			require.Len(t, row.Tags, 1)
+			assert.Equal(t, topicKey, row.Tags[0].Key)
+			if _, ok := expected[row.Tags[0].Value]; !ok {
+				// Rows recorded by the other tests of the package.
+				continue
+			}
+			seen++
+			assert.Equal(t, expected[row.Tags[0].Value], row.Data.(*view.SumData).Value, name)
+		}
+		assert.Equal(t, len(expected), seen, name)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinotmetricsexporter
+
+import (
+	"hash/fnv"
+	"sort"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+)
+
+// MetricRow is a single datapoint flattened into one Pinot row.
+type MetricRow struct {
+	MetricName     string            `json:"metricName"`
+	MetricType     string            `json:"metricType"`
+	Unit           string            `json:"unit,omitempty"`
+	Temporality    string            `json:"temporality,omitempty"`
+	IsMonotonic    bool              `json:"isMonotonic"`
+	ServiceName    string            `json:"serviceName"`
+	Labels         map[string]string `json:"labels"`
+	Fingerprint    int64             `json:"fingerprint"`
+	TimestampMs    int64             `json:"timestampMs"`
+	Value          float64           `json:"value"`
+	Count          uint64            `json:"count"`
+	Sum            float64           `json:"sum"`
+	BucketCounts   []uint64          `json:"bucketCounts,omitempty"`
+	ExplicitBounds []float64         `json:"explicitBounds,omitempty"`
+}
+
+// metricsToRows flattens gauge, sum and histogram datapoints into rows.
+// Resource attributes are merged into the labels, datapoint attributes win on conflict.
+// Metrics of other types are skipped and counted in dropped.
+func metricsToRows(md pdata.Metrics) (rows []*MetricRow, dropped int) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resourceLabels := attributesToLabels(rm.Resource().Attributes())
+		serviceName := resourceLabels[conventions.AttributeServiceName]
+
+		ilms := rm.InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				switch metric.DataType() {
+				case pdata.MetricDataTypeGauge:
+					dps := metric.Gauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						row := newRow(metric, serviceName, resourceLabels, dps.At(l).Attributes(), dps.At(l).Timestamp())
+						row.Value = numberValue(dps.At(l))
+						rows = append(rows, row)
+					}
+				case pdata.MetricDataTypeSum:
+					sum := metric.Sum()
+					dps := sum.DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						row := newRow(metric, serviceName, resourceLabels, dps.At(l).Attributes(), dps.At(l).Timestamp())
+						row.Temporality = sum.AggregationTemporality().String()
+						row.IsMonotonic = sum.IsMonotonic()
+						row.Value = numberValue(dps.At(l))
+						rows = append(rows, row)
+					}
+				case pdata.MetricDataTypeHistogram:
+					histogram := metric.Histogram()
+					dps := histogram.DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						row := newRow(metric, serviceName, resourceLabels, dp.Attributes(), dp.Timestamp())
+						row.Temporality = histogram.AggregationTemporality().String()
+						row.Count = dp.Count()
+						row.Sum = dp.Sum()
+						row.BucketCounts = dp.BucketCounts()
+						row.ExplicitBounds = dp.ExplicitBounds()
+						rows = append(rows, row)
+					}
+				default:
+					dropped++
+				}
+			}
+		}
+	}
+	return rows, dropped
+}
+
+func newRow(metric pdata.Metric, serviceName string, resourceLabels map[string]string, attributes pdata.AttributeMap, ts pdata.Timestamp) *MetricRow {
+	labels := make(map[string]string, len(resourceLabels)+attributes.Len())
+	for k, v := range resourceLabels {
+		labels[k] = v
+	}
+	attributes.Range(func(k string, v pdata.AttributeValue) bool {
+		labels[k] = v.AsString()
+		return true
+	})
+
+	return &MetricRow{
+		MetricName:  metric.Name(),
+		MetricType:  metric.DataType().String(),
+		Unit:        metric.Unit(),
+		ServiceName: serviceName,
+		Labels:      labels,
+		Fingerprint: fingerprint(metric.Name(), labels),
+		TimestampMs: ts.AsTime().UnixMilli(),
+	}
+}
+
+func numberValue(dp pdata.NumberDataPoint) float64 {
+	if dp.ValueType() == pdata.MetricValueTypeInt {
+		return float64(dp.IntVal())
+	}
+	return dp.DoubleVal()
+}
+
+func attributesToLabels(attributes pdata.AttributeMap) map[string]string {
+	labels := make(map[string]string, attributes.Len())
+	attributes.Range(func(k string, v pdata.AttributeValue) bool {
+		labels[k] = v.AsString()
+		return true
+	})
+	return labels
+}
+
+// fingerprint identifies a time series by its name and sorted labels, so Pinot
+// queries can group datapoints of a series without parsing the labels column.
+func fingerprint(name string, labels map[string]string) int64 {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	for _, k := range keys {
+		h.Write([]byte{0xff})
+		h.Write([]byte(k))
+		h.Write([]byte{0xff})
+		h.Write([]byte(labels[k]))
+	}
+	return int64(h.Sum64())
+}
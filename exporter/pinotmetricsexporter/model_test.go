@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinotmetricsexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func testMetrics(ts time.Time) pdata.Metrics {
+	md := pdata.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().InsertString("service.name", "frontend")
+	rm.Resource().Attributes().InsertString("host.name", "host-1")
+	metrics := rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("queue.size")
+	gauge.SetDataType(pdata.MetricDataTypeGauge)
+	dp := gauge.Gauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pdata.NewTimestampFromTime(ts))
+	dp.SetIntVal(42)
+	dp.Attributes().InsertString("host.name", "host-2")
+
+	sum := metrics.AppendEmpty()
+	sum.SetName("requests")
+	sum.SetUnit("1")
+	sum.SetDataType(pdata.MetricDataTypeSum)
+	sum.Sum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+	dp = sum.Sum().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pdata.NewTimestampFromTime(ts))
+	dp.SetDoubleVal(1.5)
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetDataType(pdata.MetricDataTypeHistogram)
+	histogram.Histogram().SetAggregationTemporality(pdata.MetricAggregationTemporalityDelta)
+	hdp := histogram.Histogram().DataPoints().AppendEmpty()
+	hdp.SetTimestamp(pdata.NewTimestampFromTime(ts))
+	hdp.SetCount(3)
+	hdp.SetSum(30)
+	hdp.SetBucketCounts([]uint64{1, 2})
+	hdp.SetExplicitBounds([]float64{10})
+
+	summary := metrics.AppendEmpty()
+	summary.SetName("summary")
+	summary.SetDataType(pdata.MetricDataTypeSummary)
+	summary.Summary().DataPoints().AppendEmpty()
+
+	return md
+}
+
+func TestMetricsToRows(t *testing.T) {
+	ts := time.Unix(1640995200, 0)
+	rows, dropped := metricsToRows(testMetrics(ts))
+	require.Len(t, rows, 3)
+	assert.Equal(t, 1, dropped)
+
+	gauge := rows[0]
+	assert.Equal(t, "queue.size", gauge.MetricName)
+	assert.Equal(t, "Gauge", gauge.MetricType)
+	assert.Equal(t, "frontend", gauge.ServiceName)
+	assert.Equal(t, float64(42), gauge.Value)
+	assert.Equal(t, ts.UnixMilli(), gauge.TimestampMs)
+	assert.Equal(t, map[string]string{"service.name": "frontend", "host.name": "host-2"}, gauge.Labels)
+
+	sum := rows[1]
+	assert.Equal(t, "requests", sum.MetricName)
+	assert.Equal(t, "1", sum.Unit)
+	assert.Equal(t, 1.5, sum.Value)
+	assert.True(t, sum.IsMonotonic)
+	assert.Equal(t, "AGGREGATION_TEMPORALITY_CUMULATIVE", sum.Temporality)
+
+	histogram := rows[2]
+	assert.Equal(t, "Histogram", histogram.MetricType)
+	assert.Equal(t, uint64(3), histogram.Count)
+	assert.Equal(t, float64(30), histogram.Sum)
+	assert.Equal(t, []uint64{1, 2}, histogram.BucketCounts)
+	assert.Equal(t, []float64{10}, histogram.ExplicitBounds)
+}
+
+func TestFingerprint(t *testing.T) {
+	a := fingerprint("requests", map[string]string{"a": "1", "b": "2"})
+	b := fingerprint("requests", map[string]string{"b": "2", "a": "1"})
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, fingerprint("requests", map[string]string{"a": "12"}))
+	assert.NotEqual(t, a, fingerprint("errors", map[string]string{"a": "1", "b": "2"}))
+}
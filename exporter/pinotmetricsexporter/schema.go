@@ -0,0 +1,203 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinotmetricsexporter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	timeColumn = "timestampMs"
+
+	// realtimeToOfflineTask is the minion task moving aged segments of a hybrid table to its offline table.
+	realtimeToOfflineTask = "RealtimeToOfflineSegmentsTask"
+)
+
+type fieldSpec struct {
+	Name             string `json:"name"`
+	DataType         string `json:"dataType"`
+	SingleValueField bool   `json:"singleValueField"`
+}
+
+type dateTimeFieldSpec struct {
+	Name        string `json:"name"`
+	DataType    string `json:"dataType"`
+	Format      string `json:"format"`
+	Granularity string `json:"granularity"`
+}
+
+type pinotSchema struct {
+	SchemaName          string              `json:"schemaName"`
+	DimensionFieldSpecs []fieldSpec         `json:"dimensionFieldSpecs"`
+	MetricFieldSpecs    []fieldSpec         `json:"metricFieldSpecs"`
+	DateTimeFieldSpecs  []dateTimeFieldSpec `json:"dateTimeFieldSpecs"`
+}
+
+type segmentsConfig struct {
+	SchemaName           string `json:"schemaName"`
+	TimeColumnName       string `json:"timeColumnName"`
+	TimeType             string `json:"timeType"`
+	ReplicasPerPartition string `json:"replicasPerPartition,omitempty"`
+	Replication          string `json:"replication,omitempty"`
+	RetentionTimeUnit    string `json:"retentionTimeUnit"`
+	RetentionTimeValue   string `json:"retentionTimeValue"`
+}
+
+type tableIndexConfig struct {
+	LoadMode             string            `json:"loadMode"`
+	InvertedIndexColumns []string          `json:"invertedIndexColumns"`
+	JSONIndexColumns     []string          `json:"jsonIndexColumns"`
+	StreamConfigs        map[string]string `json:"streamConfigs,omitempty"`
+}
+
+type tableTaskConfig struct {
+	TaskTypeConfigsMap map[string]map[string]string `json:"taskTypeConfigsMap"`
+}
+
+type pinotTableConfig struct {
+	TableName        string            `json:"tableName"`
+	TableType        string            `json:"tableType"`
+	SegmentsConfig   segmentsConfig    `json:"segmentsConfig"`
+	Tenants          map[string]string `json:"tenants"`
+	TableIndexConfig tableIndexConfig  `json:"tableIndexConfig"`
+	Task             *tableTaskConfig  `json:"task,omitempty"`
+	Metadata         map[string]string `json:"metadata"`
+}
+
+func newMetricsSchema(name string) *pinotSchema {
+	return &pinotSchema{
+		SchemaName: name,
+		DimensionFieldSpecs: []fieldSpec{
+			{Name: "metricName", DataType: "STRING", SingleValueField: true},
+			{Name: "metricType", DataType: "STRING", SingleValueField: true},
+			{Name: "unit", DataType: "STRING", SingleValueField: true},
+			{Name: "temporality", DataType: "STRING", SingleValueField: true},
+			{Name: "isMonotonic", DataType: "BOOLEAN", SingleValueField: true},
+			{Name: "serviceName", DataType: "STRING", SingleValueField: true},
+			{Name: "labels", DataType: "JSON", SingleValueField: true},
+			{Name: "fingerprint", DataType: "LONG", SingleValueField: true},
+			{Name: "bucketCounts", DataType: "LONG", SingleValueField: false},
+			{Name: "explicitBounds", DataType: "DOUBLE", SingleValueField: false},
+		},
+		MetricFieldSpecs: []fieldSpec{
+			{Name: "value", DataType: "DOUBLE", SingleValueField: true},
+			{Name: "count", DataType: "LONG", SingleValueField: true},
+			{Name: "sum", DataType: "DOUBLE", SingleValueField: true},
+		},
+		DateTimeFieldSpecs: []dateTimeFieldSpec{
+			{Name: timeColumn, DataType: "LONG", Format: "1:MILLISECONDS:EPOCH", Granularity: "1:MILLISECONDS"},
+		},
+	}
+}
+
+func newMetricsTableConfig(cfg *Config) *pinotTableConfig {
+	streamBrokers := cfg.Provisioning.StreamBrokers
+	if len(streamBrokers) == 0 {
+		streamBrokers = cfg.Brokers
+	}
+	tasks := make(map[string]map[string]string, len(cfg.Provisioning.Tasks)+1)
+	for taskType, taskConfig := range cfg.Provisioning.Tasks {
+		tasks[taskType] = taskConfig
+	}
+	offline := cfg.Provisioning.Offline
+	if _, ok := tasks[realtimeToOfflineTask]; !ok && offline.Enabled {
+		tasks[realtimeToOfflineTask] = map[string]string{
+			"bucketTimePeriod": formatPeriod(offline.BucketTimePeriod),
+			"bufferTimePeriod": formatPeriod(offline.BufferTimePeriod),
+		}
+	}
+	var task *tableTaskConfig
+	if len(tasks) > 0 {
+		task = &tableTaskConfig{TaskTypeConfigsMap: tasks}
+	}
+	stream := cfg.Provisioning.Stream
+	streamConfigs := map[string]string{
+		"streamType":                                   "kafka",
+		"stream.kafka.consumer.type":                   stream.ConsumerType,
+		"stream.kafka.topic.name":                      cfg.Topic,
+		"stream.kafka.broker.list":                     strings.Join(streamBrokers, ","),
+		"stream.kafka.decoder.class.name":              "org.apache.pinot.plugin.stream.kafka.KafkaJSONMessageDecoder",
+		"stream.kafka.consumer.factory.class.name":     "org.apache.pinot.plugin.stream.kafka20.KafkaConsumerFactory",
+		"stream.kafka.consumer.prop.auto.offset.reset": stream.OffsetReset,
+	}
+	if stream.FlushThresholdRows > 0 {
+		streamConfigs["realtime.segment.flush.threshold.rows"] = strconv.Itoa(stream.FlushThresholdRows)
+	}
+	if stream.FlushThresholdTime > 0 {
+		streamConfigs["realtime.segment.flush.threshold.time"] = formatPeriod(stream.FlushThresholdTime)
+	}
+	if stream.FlushThresholdSegmentSize != "" {
+		streamConfigs["realtime.segment.flush.threshold.segment.size"] = stream.FlushThresholdSegmentSize
+	}
+	return &pinotTableConfig{
+		TableName: cfg.Provisioning.Table,
+		TableType: "REALTIME",
+		SegmentsConfig: segmentsConfig{
+			SchemaName:           cfg.Provisioning.Table,
+			TimeColumnName:       timeColumn,
+			TimeType:             "MILLISECONDS",
+			ReplicasPerPartition: strconv.Itoa(cfg.Provisioning.Replication),
+			RetentionTimeUnit:    "DAYS",
+			RetentionTimeValue:   strconv.Itoa(cfg.Provisioning.RetentionDays),
+		},
+		Tenants: map[string]string{},
+		TableIndexConfig: tableIndexConfig{
+			LoadMode:             "MMAP",
+			InvertedIndexColumns: []string{"metricName", "serviceName"},
+			JSONIndexColumns:     []string{"labels"},
+			StreamConfigs:        streamConfigs,
+		},
+		Task:     task,
+		Metadata: map[string]string{},
+	}
+}
+
+// newMetricsOfflineTableConfig returns the offline table of the hybrid metrics table.
+func newMetricsOfflineTableConfig(cfg *Config) *pinotTableConfig {
+	return &pinotTableConfig{
+		TableName: cfg.Provisioning.Table,
+		TableType: "OFFLINE",
+		SegmentsConfig: segmentsConfig{
+			SchemaName:         cfg.Provisioning.Table,
+			TimeColumnName:     timeColumn,
+			TimeType:           "MILLISECONDS",
+			Replication:        strconv.Itoa(cfg.Provisioning.Replication),
+			RetentionTimeUnit:  "DAYS",
+			RetentionTimeValue: strconv.Itoa(cfg.Provisioning.Offline.RetentionDays),
+		},
+		Tenants: map[string]string{},
+		TableIndexConfig: tableIndexConfig{
+			LoadMode:             "MMAP",
+			InvertedIndexColumns: []string{"metricName", "serviceName"},
+			JSONIndexColumns:     []string{"labels"},
+		},
+		Metadata: map[string]string{},
+	}
+}
+
+// formatPeriod formats d in the period syntax of Pinot, e.g. 6h or 90m.
+func formatPeriod(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	default:
+		return fmt.Sprintf("%ds", d/time.Second)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinotmetricsexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsTableConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Brokers = []string{"kafka-1:9092", "kafka-2:9092"}
+	table := newMetricsTableConfig(cfg)
+
+	assert.Equal(t, "signoz_metrics", table.TableName)
+	assert.Equal(t, "REALTIME", table.TableType)
+	assert.Equal(t, "7", table.SegmentsConfig.RetentionTimeValue)
+	assert.Equal(t, "signoz_metrics", table.TableIndexConfig.StreamConfigs["stream.kafka.topic.name"])
+	assert.Equal(t, "kafka-1:9092,kafka-2:9092", table.TableIndexConfig.StreamConfigs["stream.kafka.broker.list"])
+
+	assert.Nil(t, table.Task)
+	assert.Equal(t, "lowlevel", table.TableIndexConfig.StreamConfigs["stream.kafka.consumer.type"])
+	assert.Equal(t, "smallest", table.TableIndexConfig.StreamConfigs["stream.kafka.consumer.prop.auto.offset.reset"])
+	assert.NotContains(t, table.TableIndexConfig.StreamConfigs, "realtime.segment.flush.threshold.rows")
+
+	cfg.Provisioning.StreamBrokers = []string{"kafka:9092"}
+	cfg.Provisioning.Tasks = map[string]map[string]string{"PurgeTask": {"schedule": "0 0 * * * ?"}}
+	table = newMetricsTableConfig(cfg)
+	assert.Equal(t, "kafka:9092", table.TableIndexConfig.StreamConfigs["stream.kafka.broker.list"])
+	assert.Equal(t, cfg.Provisioning.Tasks, table.Task.TaskTypeConfigsMap)
+
+	cfg.Provisioning.Stream = StreamSettings{
+		FlushThresholdRows:        500000,
+		FlushThresholdTime:        90 * time.Minute,
+		FlushThresholdSegmentSize: "200M",
+		OffsetReset:               "largest",
+		ConsumerType:              "lowlevel",
+	}
+	table = newMetricsTableConfig(cfg)
+	assert.Equal(t, "500000", table.TableIndexConfig.StreamConfigs["realtime.segment.flush.threshold.rows"])
+	assert.Equal(t, "90m", table.TableIndexConfig.StreamConfigs["realtime.segment.flush.threshold.time"])
+	assert.Equal(t, "200M", table.TableIndexConfig.StreamConfigs["realtime.segment.flush.threshold.segment.size"])
+	assert.Equal(t, "largest", table.TableIndexConfig.StreamConfigs["stream.kafka.consumer.prop.auto.offset.reset"])
+}
+
+func TestMetricsHybridTableConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Provisioning.Offline.Enabled = true
+	table := newMetricsTableConfig(cfg)
+	assert.Equal(t, map[string]map[string]string{
+		"RealtimeToOfflineSegmentsTask": {"bucketTimePeriod": "24h", "bufferTimePeriod": "48h"},
+	}, table.Task.TaskTypeConfigsMap)
+
+	// A task config of the user takes precedence.
+	cfg.Provisioning.Tasks = map[string]map[string]string{"RealtimeToOfflineSegmentsTask": {"bucketTimePeriod": "6h"}}
+	table = newMetricsTableConfig(cfg)
+	assert.Equal(t, cfg.Provisioning.Tasks, table.Task.TaskTypeConfigsMap)
+
+	offline := newMetricsOfflineTableConfig(cfg)
+	assert.Equal(t, "signoz_metrics", offline.TableName)
+	assert.Equal(t, "OFFLINE", offline.TableType)
+	assert.Equal(t, "signoz_metrics", offline.SegmentsConfig.SchemaName)
+	assert.Equal(t, "1", offline.SegmentsConfig.Replication)
+	assert.Equal(t, "30", offline.SegmentsConfig.RetentionTimeValue)
+	assert.Nil(t, offline.TableIndexConfig.StreamConfigs)
+}
+
+func TestFormatPeriod(t *testing.T) {
+	assert.Equal(t, "6h", formatPeriod(6*time.Hour))
+	assert.Equal(t, "90m", formatPeriod(90*time.Minute))
+	assert.Equal(t, "45s", formatPeriod(45*time.Second))
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signozauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/signozauthextension"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/config"
+	"go.uber.org/multierr"
+)
+
+// Config defines the configuration for the signozauth extension.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"`
+
+	// Header is the HTTP header or gRPC metadata key carrying the API key.
+	Header string `mapstructure:"header"`
+
+	// Tenants are the tenants allowed to send data, each with its API keys.
+	Tenants []TenantSettings `mapstructure:"tenants"`
+}
+
+// TenantSettings defines a tenant and the API keys it authenticates with.
+type TenantSettings struct {
+	// Name identifies the tenant in the authentication data of its requests.
+	Name string `mapstructure:"name"`
+
+	// APIKeys are the keys of the tenant. Several keys allow rotating them
+	// without downtime.
+	APIKeys []string `mapstructure:"api_keys"`
+}
+
+var _ config.Extension = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Header == "" {
+		return errors.New("header must not be empty")
+	}
+	if len(cfg.Tenants) == 0 {
+		return errors.New("tenants must not be empty")
+	}
+
+	var err error
+	tenants := make(map[string]string)
+	for i, tenant := range cfg.Tenants {
+		if tenant.Name == "" {
+			err = multierr.Append(err, fmt.Errorf("tenants[%d].name must not be empty", i))
+		}
+		if len(tenant.APIKeys) == 0 {
+			err = multierr.Append(err, fmt.Errorf("tenants[%d].api_keys must not be empty", i))
+		}
+		for j, key := range tenant.APIKeys {
+			if key == "" {
+				err = multierr.Append(err, fmt.Errorf("tenants[%d].api_keys[%d] must not be empty", i, j))
+				continue
+			}
+			if other, ok := tenants[key]; ok {
+				err = multierr.Append(err, fmt.Errorf("tenants[%d].api_keys[%d] is already a key of tenant %q", i, j, other))
+				continue
+			}
+			tenants[key] = tenant.Name
+		}
+	}
+	return err
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signozauthextension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/servicetest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Extensions[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.Len(t, cfg.Extensions, 2)
+
+	ext := cfg.Extensions[config.NewComponentID(typeStr)].(*Config)
+	assert.Equal(t, defaultHeader, ext.Header)
+	assert.Equal(t, []TenantSettings{
+		{Name: "acme", APIKeys: []string{"acme-key-1", "acme-key-2"}},
+		{Name: "globex", APIKeys: []string{"globex-key"}},
+	}, ext.Tenants)
+
+	custom := cfg.Extensions[config.NewComponentIDWithName(typeStr, "custom")].(*Config)
+	assert.Equal(t, "x-api-key", custom.Header)
+}
+
+func TestValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.Validate(), "tenants must not be empty")
+
+	cfg.Tenants = []TenantSettings{
+		{Name: "acme", APIKeys: []string{"key"}},
+		{Name: "", APIKeys: []string{"", "key"}},
+		{Name: "globex"},
+	}
+	assert.EqualError(t, cfg.Validate(), "tenants[1].name must not be empty; "+
+		"tenants[1].api_keys[0] must not be empty; "+
+		"tenants[1].api_keys[1] is already a key of tenant \"acme\"; "+
+		"tenants[2].api_keys must not be empty")
+
+	cfg.Tenants = []TenantSettings{{Name: "acme", APIKeys: []string{"key"}}}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Header = ""
+	assert.EqualError(t, cfg.Validate(), "header must not be empty")
+}
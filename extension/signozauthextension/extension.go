@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signozauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/signozauthextension"
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/config/configauth"
+)
+
+// tenantAttribute is the name of the authentication data attribute holding
+// the tenant, read by processors with from_auth: tenant.
+const tenantAttribute = "tenant"
+
+var (
+	errNoAPIKey      = errors.New("no API key provided")
+	errInvalidAPIKey = errors.New("invalid API key")
+)
+
+type tenantAuth struct {
+	header string
+	keys   []apiKey
+}
+
+// apiKey is an API key and the name of its tenant.
+type apiKey struct {
+	key    []byte
+	tenant string
+}
+
+func newExtension(cfg *Config) configauth.ServerAuthenticator {
+	ta := &tenantAuth{header: cfg.Header}
+	for _, tenant := range cfg.Tenants {
+		for _, key := range tenant.APIKeys {
+			ta.keys = append(ta.keys, apiKey{key: []byte(key), tenant: tenant.Name})
+		}
+	}
+	return configauth.NewServerAuthenticator(configauth.WithAuthenticate(ta.authenticate))
+}
+
+func (ta *tenantAuth) authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	key := getHeader(headers, ta.header)
+	if key == "" {
+		return ctx, errNoAPIKey
+	}
+	tenant, ok := ta.lookup([]byte(key))
+	if !ok {
+		return ctx, errInvalidAPIKey
+	}
+
+	cl := client.FromContext(ctx)
+	cl.Auth = &authData{tenant: tenant}
+	return client.NewContext(ctx, cl), nil
+}
+
+// lookup returns the tenant of the key. Every configured key is compared in
+// constant time, so the time taken doesn't tell how close a guess was.
+func (ta *tenantAuth) lookup(key []byte) (string, bool) {
+	tenant, found := "", false
+	for _, k := range ta.keys {
+		if subtle.ConstantTimeCompare(k.key, key) == 1 {
+			tenant, found = k.tenant, true
+		}
+	}
+	return tenant, found
+}
+
+// getHeader returns the first value of the header. HTTP headers arrive in
+// canonical form and gRPC metadata keys in lower case, so the name is matched
+// case-insensitively.
+func getHeader(headers map[string][]string, name string) string {
+	for k, v := range headers {
+		if strings.EqualFold(k, name) && len(v) > 0 {
+			return v[0]
+		}
+	}
+	return ""
+}
+
+var _ client.AuthData = (*authData)(nil)
+
+type authData struct {
+	tenant string
+}
+
+func (a *authData) GetAttribute(name string) interface{} {
+	if name == tenantAttribute {
+		return a.tenant
+	}
+	return nil
+}
+
+func (*authData) GetAttributeNames() []string {
+	return []string{tenantAttribute}
+}
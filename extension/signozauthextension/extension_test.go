@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signozauthextension
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+)
+
+func newTestConfig() *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Tenants = []TenantSettings{
+		{Name: "acme", APIKeys: []string{"acme-key-1", "acme-key-2"}},
+		{Name: "globex", APIKeys: []string{"globex-key"}},
+	}
+	return cfg
+}
+
+func TestAuthenticate(t *testing.T) {
+	ext := newExtension(newTestConfig())
+
+	testCases := []struct {
+		desc    string
+		headers map[string][]string
+		tenant  string
+	}{
+		{
+			desc:    "http header",
+			headers: map[string][]string{"Signoz-Access-Token": {"acme-key-2"}},
+			tenant:  "acme",
+		},
+		{
+			desc:    "grpc metadata",
+			headers: map[string][]string{"signoz-access-token": {"globex-key"}},
+			tenant:  "globex",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx, err := ext.Authenticate(context.Background(), tc.headers)
+			require.NoError(t, err)
+
+			auth := client.FromContext(ctx).Auth
+			require.NotNil(t, auth)
+			assert.Equal(t, tc.tenant, auth.GetAttribute("tenant"))
+			assert.Equal(t, []string{"tenant"}, auth.GetAttributeNames())
+			assert.Nil(t, auth.GetAttribute("username"))
+		})
+	}
+}
+
+func TestAuthenticateKeepsClientInfo(t *testing.T) {
+	ext := newExtension(newTestConfig())
+
+	md := client.NewMetadata(map[string][]string{"k": {"v"}})
+	ctx := client.NewContext(context.Background(), client.Info{Metadata: md})
+	ctx, err := ext.Authenticate(ctx, map[string][]string{"signoz-access-token": {"acme-key-1"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"v"}, client.FromContext(ctx).Metadata.Get("k"))
+}
+
+func TestAuthenticateErrors(t *testing.T) {
+	ext := newExtension(newTestConfig())
+
+	_, err := ext.Authenticate(context.Background(), map[string][]string{})
+	assert.ErrorIs(t, err, errNoAPIKey)
+
+	_, err = ext.Authenticate(context.Background(), map[string][]string{"signoz-access-token": {""}})
+	assert.ErrorIs(t, err, errNoAPIKey)
+
+	_, err = ext.Authenticate(context.Background(), map[string][]string{"signoz-access-token": {"unknown"}})
+	assert.ErrorIs(t, err, errInvalidAPIKey)
+}
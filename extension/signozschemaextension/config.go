@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signozschemaextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/signozschemaextension"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pinot"
+)
+
+// Config defines the configuration for the signozschema extension.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"`
+
+	// HTTPClientSettings points at the Pinot controller, e.g. http://pinot-controller:9000.
+	confighttp.HTTPClientSettings `mapstructure:",squash"`
+
+	// Retry defines how failed requests against the controller are retried.
+	Retry pinot.RetrySettings `mapstructure:"retry_on_failure"`
+
+	// DryRun renders the schemas and tables instead of creating them.
+	DryRun DryRun `mapstructure:"dry_run"`
+}
+
+// DryRun defines how schemas and tables are rendered for review instead of being applied.
+type DryRun struct {
+	// Enabled logs the JSON the extension would post to the controller and does not contact it.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Directory, when set, also receives the rendered JSON as <name>_schema.json and <name>_table.json.
+	Directory string `mapstructure:"directory"`
+}
+
+var _ config.Extension = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint must not be empty")
+	}
+	return nil
+}
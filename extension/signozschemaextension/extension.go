@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signozschemaextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/signozschemaextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pinot"
+)
+
+var (
+	errSchemaManagerNotFound = errors.New("schema manager not found")
+	errNotSchemaManager      = errors.New("requested extension is not a schema manager")
+	errNotStarted            = errors.New("schema manager is not started")
+)
+
+// SchemaManager creates the Pinot schemas and tables the SigNoz exporters write to.
+// Exporters reference the extension by ID so that all of them share a single
+// controller client, and provisioning happens once per collector.
+type SchemaManager interface {
+	component.Extension
+
+	// EnsureTable creates the named schema and then the table, which references
+	// it, unless they already exist. schema and table are marshaled to JSON as
+	// is. Calls are serialized, and a name that was provisioned before is not
+	// sent to the controller again.
+	EnsureTable(ctx context.Context, name string, schema, table interface{}) error
+}
+
+// GetSchemaManager returns the SchemaManager registered under id.
+func GetSchemaManager(extensions map[config.ComponentID]component.Extension, id config.ComponentID) (SchemaManager, error) {
+	ext, ok := extensions[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errSchemaManagerNotFound, id)
+	}
+	sm, ok := ext.(SchemaManager)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errNotSchemaManager, id)
+	}
+	return sm, nil
+}
+
+type schemaManager struct {
+	config   *Config
+	settings component.TelemetrySettings
+	logger   *zap.Logger
+	client   *pinot.Client
+
+	mu          sync.Mutex
+	provisioned map[string]struct{}
+}
+
+var _ SchemaManager = (*schemaManager)(nil)
+
+func newSchemaManager(cfg *Config, settings component.TelemetrySettings) *schemaManager {
+	return &schemaManager{
+		config:      cfg,
+		settings:    settings,
+		logger:      settings.Logger,
+		provisioned: make(map[string]struct{}),
+	}
+}
+
+func (m *schemaManager) Start(_ context.Context, host component.Host) error {
+	client, err := m.config.ToClient(host.GetExtensions(), m.settings)
+	if err != nil {
+		return err
+	}
+	m.client = pinot.NewClient(m.config.Endpoint, client, m.config.Retry, m.logger)
+	return nil
+}
+
+func (m *schemaManager) Shutdown(context.Context) error {
+	return nil
+}
+
+func (m *schemaManager) EnsureTable(ctx context.Context, name string, schema, table interface{}) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.provisioned[name]; ok {
+		return nil
+	}
+	if m.config.DryRun.Enabled {
+		if err := m.render(name, schema, table); err != nil {
+			return err
+		}
+		m.provisioned[name] = struct{}{}
+		return nil
+	}
+	if m.client == nil {
+		return errNotStarted
+	}
+	// Objects that already exist are left untouched.
+	if err := m.client.CreateSchema(ctx, schema); err != nil && !pinot.IsConflict(err) {
+		return fmt.Errorf("failed to create schema %s: %w", name, err)
+	}
+	if err := m.client.CreateTable(ctx, table); err != nil && !pinot.IsConflict(err) {
+		return fmt.Errorf("failed to create table %s: %w", name, err)
+	}
+	m.provisioned[name] = struct{}{}
+	m.logger.Info("Pinot table provisioned", zap.String("table", name))
+	return nil
+}
+
+// render logs the schema and table JSON and writes it to the dry run directory, if any.
+// They are rendered in the order they are applied in, so dry run output can be diffed.
+func (m *schemaManager) render(name string, schema, table interface{}) error {
+	for _, object := range []struct {
+		kind string
+		body interface{}
+	}{{"schema", schema}, {"table", table}} {
+		kind := object.kind
+		payload, err := json.MarshalIndent(object.body, "", "  ")
+		if err != nil {
+			return err
+		}
+		m.logger.Info("Dry run, not applying Pinot "+kind, zap.String("name", name), zap.ByteString(kind, payload))
+		if m.config.DryRun.Directory == "" {
+			continue
+		}
+		path := filepath.Join(m.config.DryRun.Directory, fmt.Sprintf("%s_%s.json", name, kind))
+		if err := ioutil.WriteFile(path, payload, 0600); err != nil {
+			return fmt.Errorf("failed to write %s of %s: %w", kind, name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signozschemaextension
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newTestManager(t *testing.T, endpoint string) *schemaManager {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	m := newSchemaManager(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, m.Start(context.Background(), componenttest.NewNopHost()))
+	return m
+}
+
+func TestEnsureTable(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.RequestURI())
+		mu.Unlock()
+		if r.URL.Path == "/schemas" {
+			w.WriteHeader(http.StatusConflict)
+		}
+	}))
+	defer server.Close()
+
+	m := newTestManager(t, server.URL)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, m.EnsureTable(context.Background(), "metrics", map[string]string{}, map[string]string{}))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, []string{"/schemas?override=false", "/tables"}, paths)
+}
+
+func TestEnsureTableError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid schema", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	m := newTestManager(t, server.URL)
+	err := m.EnsureTable(context.Background(), "metrics", map[string]string{}, map[string]string{})
+	assert.EqualError(t, err, fmt.Sprintf(`failed to create schema metrics: request POST %s/schemas?override=false failed - "400 Bad Request": invalid schema`, server.URL))
+}
+
+func TestEnsureTableRetries(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.Retry.InitialInterval = time.Millisecond
+	m := newSchemaManager(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, m.Start(context.Background(), componenttest.NewNopHost()))
+
+	assert.NoError(t, m.EnsureTable(context.Background(), "metrics", map[string]string{}, map[string]string{}))
+	assert.Equal(t, 3, calls)
+}
+
+func TestEnsureTableNotStarted(t *testing.T) {
+	m := newSchemaManager(createDefaultConfig().(*Config), componenttest.NewNopTelemetrySettings())
+	assert.ErrorIs(t, m.EnsureTable(context.Background(), "metrics", nil, nil), errNotStarted)
+}
+
+func TestGetSchemaManager(t *testing.T) {
+	id := config.NewComponentID(typeStr)
+	m := newSchemaManager(createDefaultConfig().(*Config), componenttest.NewNopTelemetrySettings())
+	nopFactory := componenttest.NewNopExtensionFactory()
+	nop, err := nopFactory.CreateExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), nopFactory.CreateDefaultConfig())
+	require.NoError(t, err)
+	extensions := map[config.ComponentID]component.Extension{
+		id:                           m,
+		config.NewComponentID("nop"): nop,
+	}
+
+	sm, err := GetSchemaManager(extensions, id)
+	require.NoError(t, err)
+	assert.Equal(t, m, sm)
+
+	_, err = GetSchemaManager(extensions, config.NewComponentIDWithName(typeStr, "missing"))
+	assert.ErrorIs(t, err, errSchemaManagerNotFound)
+
+	_, err = GetSchemaManager(extensions, config.NewComponentID("nop"))
+	assert.ErrorIs(t, err, errNotSchemaManager)
+}
+
+func TestEnsureTableDryRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.URL
+	cfg.DryRun = DryRun{Enabled: true, Directory: t.TempDir()}
+	core, logs := observer.New(zapcore.InfoLevel)
+	settings := componenttest.NewNopTelemetrySettings()
+	settings.Logger = zap.New(core)
+	m := newSchemaManager(cfg, settings)
+
+	require.NoError(t, m.EnsureTable(context.Background(), "metrics", map[string]string{"schemaName": "metrics"}, map[string]string{"tableName": "metrics"}))
+
+	schema, err := ioutil.ReadFile(filepath.Join(cfg.DryRun.Directory, "metrics_schema.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"schemaName": "metrics"}`, string(schema))
+	table, err := ioutil.ReadFile(filepath.Join(cfg.DryRun.Directory, "metrics_table.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"tableName": "metrics"}`, string(table))
+
+	// The schema is rendered before the table, as it is applied.
+	require.Equal(t, 2, logs.Len())
+	assert.Equal(t, "Dry run, not applying Pinot schema", logs.All()[0].Message)
+	assert.Equal(t, "Dry run, not applying Pinot table", logs.All()[1].Message)
+}
@@ -58,6 +58,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/newrelicexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/opencensusexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/parquetexporter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/pinotmetricsexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sapmexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sentryexporter"
@@ -159,6 +160,16 @@ func TestDefaultExporters(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			exporter: "pinotmetrics",
+			getConfigFn: func() config.Exporter {
+				cfg := expFactories["pinotmetrics"].CreateDefaultConfig().(*pinotmetricsexporter.Config)
+				cfg.Brokers = []string{"invalid:9092"}
+				// this disables contacting the broker so we can successfully create the exporter
+				cfg.Metadata.Full = false
+				return cfg
+			},
+		},
 		{
 			exporter: "prometheus",
 			getConfigFn: func() config.Exporter {
@@ -192,6 +192,12 @@ func TestDefaultExtensions(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			extension: "signozauth",
+		},
+		{
+			extension: "signozschema",
+		},
 		{
 			extension:     "k8s_observer",
 			skipLifecycle: true, // Requires a K8s api to interfact with and validate
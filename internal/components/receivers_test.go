@@ -161,6 +161,15 @@ func TestDefaultReceivers(t *testing.T) {
 		{
 			receiver: "otlp",
 		},
+		{
+			receiver: "pinot",
+		},
+		{
+			receiver: "pinotquery",
+		},
+		{
+			receiver: "pinotevents",
+		},
 		{
 			receiver:     "podman_stats",
 			skipLifecyle: true, // Requires a running podman daemon
@@ -64,20 +64,28 @@ type ActionKeyValue struct {
 	// If the key has multiple values the values will be joined with `;` separator.
 	FromContext string `mapstructure:"from_context"`
 
+	// FromAuth specifies the authentication attribute to use to populate
+	// the value. The values would be searched in client.Info.Auth, set by
+	// the server authenticator of the receiver.
+	// If the attribute doesn't exist or isn't a string or a list of strings,
+	// no action is performed.
+	// If the attribute has multiple values the values will be joined with `;` separator.
+	FromAuth string `mapstructure:"from_auth"`
+
 	// Action specifies the type of action to perform.
 	// The set of values are {INSERT, UPDATE, UPSERT, DELETE, HASH}.
 	// Both lower case and upper case are supported.
 	// INSERT -  Inserts the key/value to attributes when the key does not exist.
 	//           No action is applied to attributes where the key already exists.
-	//           Either Value, FromAttribute or FromContext must be set.
+	//           Either Value, FromAttribute, FromContext or FromAuth must be set.
 	// UPDATE -  Updates an existing key with a value. No action is applied
 	//           to attributes where the key does not exist.
-	//           Either Value, FromAttribute or FromContext must be set.
+	//           Either Value, FromAttribute, FromContext or FromAuth must be set.
 	// UPSERT -  Performs insert or update action depending on the attributes
 	//           containing the key. The key/value is inserted to attributes
 	//           that did not originally have the key. The key/value is updated
 	//           for attributes where the key already existed.
-	//           Either Value, FromAttribute or FromContext must be set.
+	//           Either Value, FromAttribute, FromContext or FromAuth must be set.
 	// DELETE  - Deletes the attribute. If the key doesn't exist,
 	//           no action is performed.
 	// HASH    - Calculates the SHA-1 hash of an existing value and overwrites the
@@ -102,6 +110,10 @@ func (a *ActionKeyValue) valueSourceCount() int {
 	if a.FromContext != "" {
 		count++
 	}
+
+	if a.FromAuth != "" {
+		count++
+	}
 	return count
 }
 
@@ -140,6 +152,7 @@ type attributeAction struct {
 	Key           string
 	FromAttribute string
 	FromContext   string
+	FromAuth      string
 	// Compiled regex if provided
 	Regex *regexp.Regexp
 	// Attribute names extracted from the regexp's subexpressions.
@@ -182,7 +195,7 @@ func NewAttrProc(settings *Settings) (*AttrProc, error) {
 		switch a.Action {
 		case INSERT, UPDATE, UPSERT:
 			if valueSourceCount == 0 {
-				return nil, fmt.Errorf("error creating AttrProc. Either field \"value\", \"from_attribute\" or \"from_context\" setting must be specified for %d-th action", i)
+				return nil, fmt.Errorf("error creating AttrProc. Either field \"value\", \"from_attribute\", \"from_context\" or \"from_auth\" setting must be specified for %d-th action", i)
 			}
 
 			if valueSourceCount > 1 {
@@ -202,6 +215,7 @@ func NewAttrProc(settings *Settings) (*AttrProc, error) {
 			} else {
 				action.FromAttribute = a.FromAttribute
 				action.FromContext = a.FromContext
+				action.FromAuth = a.FromAuth
 			}
 		case HASH, DELETE:
 			if valueSourceCount > 0 || a.RegexPattern != "" {
@@ -287,6 +301,27 @@ func getAttributeValueFromContext(ctx context.Context, key string) (pdata.Attrib
 	return pdata.NewAttributeValueString(strings.Join(vals, ";")), true
 }
 
+func getAttributeValueFromAuth(ctx context.Context, name string) (pdata.AttributeValue, bool) {
+	ci := client.FromContext(ctx)
+	if ci.Auth == nil {
+		return pdata.AttributeValue{}, false
+	}
+
+	var vals []string
+	switch v := ci.Auth.GetAttribute(name).(type) {
+	case string:
+		vals = []string{v}
+	case []string:
+		vals = v
+	}
+
+	if len(vals) == 0 {
+		return pdata.AttributeValue{}, false
+	}
+
+	return pdata.NewAttributeValueString(strings.Join(vals, ";")), true
+}
+
 func getSourceAttributeValue(ctx context.Context, action attributeAction, attrs pdata.AttributeMap) (pdata.AttributeValue, bool) {
 	// Set the key with a value from the configuration.
 	if action.AttributeValue != nil {
@@ -297,6 +332,10 @@ func getSourceAttributeValue(ctx context.Context, action attributeAction, attrs
 		return getAttributeValueFromContext(ctx, action.FromContext)
 	}
 
+	if action.FromAuth != "" {
+		return getAttributeValueFromAuth(ctx, action.FromAuth)
+	}
+
 	return attrs.Get(action.FromAttribute)
 }
 
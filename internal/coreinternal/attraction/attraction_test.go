@@ -772,7 +772,7 @@ func TestInvalidConfig(t *testing.T) {
 			actionLists: []ActionKeyValue{
 				{Key: "MissingValueFromAttributes", Action: INSERT},
 			},
-			errorString: "error creating AttrProc. Either field \"value\", \"from_attribute\" or \"from_context\" setting must be specified for 0-th action",
+			errorString: "error creating AttrProc. Either field \"value\", \"from_attribute\", \"from_context\" or \"from_auth\" setting must be specified for 0-th action",
 		},
 		{
 			name: "both set value and from attribute",
@@ -881,6 +881,22 @@ func sha1Hash(b []byte) string {
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
 
+type testAuthData struct {
+	attrs map[string]interface{}
+}
+
+func (a *testAuthData) GetAttribute(name string) interface{} {
+	return a.attrs[name]
+}
+
+func (a *testAuthData) GetAttributeNames() []string {
+	names := make([]string, 0, len(a.attrs))
+	for name := range a.attrs {
+		names = append(names, name)
+	}
+	return names
+}
+
 func TestFromContext(t *testing.T) {
 
 	mdCtx := client.NewContext(context.TODO(), client.Info{
@@ -888,6 +904,11 @@ func TestFromContext(t *testing.T) {
 			"source_single_val":   {"single_val"},
 			"source_multiple_val": {"first_val", "second_val"},
 		}),
+		Auth: &testAuthData{attrs: map[string]interface{}{
+			"tenant":     "acme",
+			"membership": []string{"dev", "ops"},
+			"expiry":     42,
+		}},
 	})
 
 	testCases := []struct {
@@ -920,6 +941,36 @@ func TestFromContext(t *testing.T) {
 			expectedAttributes: map[string]pdata.AttributeValue{"dest": pdata.NewAttributeValueString("first_val;second_val")},
 			action:             &ActionKeyValue{Key: "dest", FromContext: "source_multiple_val", Action: INSERT},
 		},
+		{
+			name:               "no_auth",
+			ctx:                context.TODO(),
+			expectedAttributes: map[string]pdata.AttributeValue{},
+			action:             &ActionKeyValue{Key: "dest", FromAuth: "tenant", Action: INSERT},
+		},
+		{
+			name:               "auth_single_value",
+			ctx:                mdCtx,
+			expectedAttributes: map[string]pdata.AttributeValue{"dest": pdata.NewAttributeValueString("acme")},
+			action:             &ActionKeyValue{Key: "dest", FromAuth: "tenant", Action: INSERT},
+		},
+		{
+			name:               "auth_multiple_values",
+			ctx:                mdCtx,
+			expectedAttributes: map[string]pdata.AttributeValue{"dest": pdata.NewAttributeValueString("dev;ops")},
+			action:             &ActionKeyValue{Key: "dest", FromAuth: "membership", Action: INSERT},
+		},
+		{
+			name:               "auth_unsupported_value",
+			ctx:                mdCtx,
+			expectedAttributes: map[string]pdata.AttributeValue{},
+			action:             &ActionKeyValue{Key: "dest", FromAuth: "expiry", Action: INSERT},
+		},
+		{
+			name:               "context_key_is_not_auth",
+			ctx:                mdCtx,
+			expectedAttributes: map[string]pdata.AttributeValue{},
+			action:             &ActionKeyValue{Key: "dest", FromContext: "tenant", Action: INSERT},
+		},
 	}
 
 	for _, tc := range testCases {
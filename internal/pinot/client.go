@@ -0,0 +1,252 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pinot contains a client for the REST API of Pinot controllers and
+// brokers shared by the Pinot components.
+package pinot // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/pinot"
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"go.uber.org/zap"
+)
+
+// RetrySettings defines how failed requests are retried.
+type RetrySettings struct {
+	// Enabled retries requests that failed with a network error or a 429 or 5xx status.
+	Enabled bool `mapstructure:"enabled"`
+	// InitialInterval is the time to wait after the first failure before retrying.
+	InitialInterval time.Duration `mapstructure:"initial_interval"`
+	// MaxInterval is the upper bound on the wait between retries.
+	MaxInterval time.Duration `mapstructure:"max_interval"`
+	// MaxElapsedTime is the time after which a request is given up. Zero retries until the context is done.
+	MaxElapsedTime time.Duration `mapstructure:"max_elapsed_time"`
+}
+
+// DefaultRetrySettings returns the default settings for RetrySettings.
+func DefaultRetrySettings() RetrySettings {
+	return RetrySettings{
+		Enabled:         true,
+		InitialInterval: 500 * time.Millisecond,
+		MaxInterval:     5 * time.Second,
+		MaxElapsedTime:  30 * time.Second,
+	}
+}
+
+// StatusError is returned for responses with a status other than 2xx.
+type StatusError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Status     string
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("request %s %s failed - %q", e.Method, e.URL, e.Status)
+	}
+	return fmt.Sprintf("request %s %s failed - %q: %s", e.Method, e.URL, e.Status, e.Message)
+}
+
+// IsConflict reports whether err is the controller refusing to create an object that already exists.
+func IsConflict(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusConflict
+}
+
+// Client makes requests against a Pinot controller or broker. Authentication
+// and TLS are up to the *http.Client it is created with, usually built from
+// confighttp.HTTPClientSettings.
+type Client struct {
+	endpoint string
+	client   *http.Client
+	retry    RetrySettings
+	logger   *zap.Logger
+}
+
+// NewClient creates a Client sending requests to endpoint, e.g. http://pinot-controller:9000.
+func NewClient(endpoint string, client *http.Client, retry RetrySettings, logger *zap.Logger) *Client {
+	return &Client{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   client,
+		retry:    retry,
+		logger:   logger,
+	}
+}
+
+// Health checks that the controller is up.
+func (c *Client) Health(ctx context.Context) error {
+	return c.Do(ctx, http.MethodGet, "/health", nil, nil)
+}
+
+// ListTables returns the names of all tables.
+func (c *Client) ListTables(ctx context.Context) ([]string, error) {
+	var resp struct {
+		Tables []string `json:"tables"`
+	}
+	if err := c.Get(ctx, "/tables", &resp); err != nil {
+		return nil, err
+	}
+	return resp.Tables, nil
+}
+
+// GetTable decodes the config of a table into v. tableType is "realtime",
+// "offline" or empty for both, keyed by upper case type.
+func (c *Client) GetTable(ctx context.Context, name, tableType string, v interface{}) error {
+	path := "/tables/" + url.PathEscape(name)
+	if tableType != "" {
+		path += "?type=" + url.QueryEscape(tableType)
+	}
+	return c.Get(ctx, path, v)
+}
+
+// CreateTable creates a table from its config. It fails with a conflict, see
+// IsConflict, when the table exists.
+func (c *Client) CreateTable(ctx context.Context, table interface{}) error {
+	return c.Post(ctx, "/tables", table, nil)
+}
+
+// DeleteTable deletes a table. tableType is "realtime", "offline" or empty for both.
+func (c *Client) DeleteTable(ctx context.Context, name, tableType string) error {
+	path := "/tables/" + url.PathEscape(name)
+	if tableType != "" {
+		path += "?type=" + url.QueryEscape(tableType)
+	}
+	return c.Do(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// GetSchema decodes the named schema into v.
+func (c *Client) GetSchema(ctx context.Context, name string, v interface{}) error {
+	return c.Get(ctx, "/schemas/"+url.PathEscape(name), v)
+}
+
+// CreateSchema creates a schema. It fails with a conflict, see IsConflict,
+// when the schema exists.
+func (c *Client) CreateSchema(ctx context.Context, schema interface{}) error {
+	return c.Post(ctx, "/schemas?override=false", schema, nil)
+}
+
+// DeleteSchema deletes the named schema. Tables using it have to be deleted first.
+func (c *Client) DeleteSchema(ctx context.Context, name string) error {
+	return c.Do(ctx, http.MethodDelete, "/schemas/"+url.PathEscape(name), nil, nil)
+}
+
+// Get sends a GET request for path and decodes the JSON response into v.
+func (c *Client) Get(ctx context.Context, path string, v interface{}) error {
+	return c.Do(ctx, http.MethodGet, path, nil, v)
+}
+
+// Post sends body as JSON to path and decodes the JSON response into v, if not nil.
+func (c *Client) Post(ctx context.Context, path string, body, v interface{}) error {
+	return c.Do(ctx, http.MethodPost, path, body, v)
+}
+
+// Do sends a request with body, if not nil, encoded as JSON and decodes the
+// response into v, if not nil. Network errors and 429 or 5xx responses are
+// retried with exponential backoff.
+func (c *Client) Do(ctx context.Context, method, path string, body, v interface{}) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	if !c.retry.Enabled {
+		return c.do(ctx, method, path, payload, v)
+	}
+
+	expBackoff := backoff.NewExponentialBackOff()
+	expBackoff.InitialInterval = c.retry.InitialInterval
+	expBackoff.MaxInterval = c.retry.MaxInterval
+	expBackoff.MaxElapsedTime = c.retry.MaxElapsedTime
+	return backoff.RetryNotify(func() error {
+		err := c.do(ctx, method, path, payload, v)
+		if err != nil && !retryable(err) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}, backoff.WithContext(expBackoff, ctx), func(err error, wait time.Duration) {
+		c.logger.Debug("Pinot request failed, retrying", zap.String("path", path), zap.Duration("wait", wait), zap.Error(err))
+	})
+}
+
+func (c *Client) do(ctx context.Context, method, path string, payload []byte, v interface{}) error {
+	var reader io.Reader
+	if payload != nil {
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, reader)
+	if err != nil {
+		return err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err = resp.Body.Close(); err != nil {
+			c.logger.Warn("failed to close response body", zap.Error(err))
+		}
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := ioutil.ReadAll(resp.Body)
+		return &StatusError{
+			Method:     method,
+			URL:        req.URL.String(),
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Message:    strings.TrimSpace(string(msg)),
+		}
+	}
+
+	if v == nil {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		return nil
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body %w", err)
+	}
+	return json.Unmarshal(respBody, v)
+}
+
+func retryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || statusErr.StatusCode >= 500
+	}
+	// The http.Client reports network errors as *url.Error, anything else is
+	// a request or response that won't get better by sending it again.
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
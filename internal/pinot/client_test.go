@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func testRetrySettings() RetrySettings {
+	return RetrySettings{
+		Enabled:         true,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		MaxElapsedTime:  time.Second,
+	}
+}
+
+func TestClientRetriesServerErrors(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"tables":["signoz_metrics"]}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL+"/", srv.Client(), testRetrySettings(), zap.NewNop())
+	tables, err := c.ListTables(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"signoz_metrics"}, tables)
+	assert.Equal(t, 3, calls)
+}
+
+func TestClientDoesNotRetryClientErrors(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte("table already exists\n"))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, srv.Client(), testRetrySettings(), zap.NewNop())
+	err := c.CreateTable(context.Background(), map[string]string{"tableName": "signoz_metrics"})
+	require.Error(t, err)
+	assert.True(t, IsConflict(err))
+	assert.Contains(t, err.Error(), "table already exists")
+	assert.Equal(t, 1, calls)
+}
+
+func TestClientRetryDisabled(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, srv.Client(), RetrySettings{}, zap.NewNop())
+	err := c.Health(context.Background())
+	require.Error(t, err)
+	assert.False(t, IsConflict(err))
+	assert.Equal(t, 1, calls)
+}
+
+func TestClientPost(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/schemas", r.URL.Path)
+		assert.Equal(t, "false", r.URL.Query().Get("override"))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var schema map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&schema))
+		assert.Equal(t, "signoz_metrics", schema["schemaName"])
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, srv.Client(), testRetrySettings(), zap.NewNop())
+	require.NoError(t, c.CreateSchema(context.Background(), map[string]string{"schemaName": "signoz_metrics"}))
+}
+
+func TestClientContextCanceled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c := NewClient(srv.URL, srv.Client(), RetrySettings{Enabled: true, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond}, zap.NewNop())
+	assert.Error(t, c.Health(ctx))
+}
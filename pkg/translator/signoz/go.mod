@@ -0,0 +1,19 @@
+module github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signoz
+
+go 1.17
+
+require (
+	github.com/cespare/xxhash/v2 v2.1.2
+	github.com/google/uuid v1.3.0
+	github.com/mailru/easyjson v0.7.7
+	github.com/stretchr/testify v1.7.0
+	go.opentelemetry.io/collector/model v0.45.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/gogo/protobuf v1.3.2 // indirect
+	github.com/josharian/intern v1.0.0 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b // indirect
+)
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signoz
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// grpcStatusNames are the canonical names of the gRPC status codes, indexed by code.
+var grpcStatusNames = []string{
+	"OK",
+	"CANCELLED",
+	"UNKNOWN",
+	"INVALID_ARGUMENT",
+	"DEADLINE_EXCEEDED",
+	"NOT_FOUND",
+	"ALREADY_EXISTS",
+	"PERMISSION_DENIED",
+	"RESOURCE_EXHAUSTED",
+	"FAILED_PRECONDITION",
+	"ABORTED",
+	"OUT_OF_RANGE",
+	"UNIMPLEMENTED",
+	"INTERNAL",
+	"UNAVAILABLE",
+	"DATA_LOSS",
+	"UNAUTHENTICATED",
+}
+
+// grpcStatusCodes maps the canonical gRPC status names to their codes.
+var grpcStatusCodes = func() map[string]int64 {
+	codes := make(map[string]int64, len(grpcStatusNames))
+	for code, name := range grpcStatusNames {
+		codes[name] = int64(code)
+	}
+	return codes
+}()
+
+// grpcStatusName returns the canonical name of a gRPC status code, or an empty string for unknown codes.
+func grpcStatusName(code int64) string {
+	if code < 0 || code >= int64(len(grpcStatusNames)) {
+		return ""
+	}
+	return grpcStatusNames[code]
+}
+
+// parseGRPCStatusCode reads a gRPC status code. Besides the numeric forms of
+// parseStatusCode, some SDKs report the status name, either plain, e.g.
+// DEADLINE_EXCEEDED, or qualified with the enum type, e.g. StatusCode.DEADLINE_EXCEEDED.
+func parseGRPCStatusCode(v pdata.AttributeValue) (int64, bool) {
+	if code, ok := parseStatusCode(v); ok {
+		return code, true
+	}
+	if v.Type() != pdata.AttributeValueTypeString {
+		return 0, false
+	}
+	name := strings.ToUpper(strings.TrimSpace(v.StringVal()))
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		name = name[i+1:]
+	}
+	code, ok := grpcStatusCodes[name]
+	return code, ok
+}
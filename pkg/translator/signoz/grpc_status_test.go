@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signoz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestPopulateOtherDimensionsGRPCStatus(t *testing.T) {
+	m, err := NewErrorMatcher(HasErrorSettings{})
+	require.NoError(t, err)
+
+	attributes := pdata.NewAttributeMap()
+	attributes.InsertInt("rpc.grpc.status_code", 4)
+	span := &Span{}
+	populateOtherDimensions(attributes, span, m)
+	assert.Equal(t, "4", span.GRPCCode)
+	assert.Equal(t, "DEADLINE_EXCEEDED", span.GRPCStatusName)
+	assert.True(t, span.HasError)
+
+	attributes.UpsertString("rpc.grpc.status_code", "not_found")
+	span = &Span{}
+	populateOtherDimensions(attributes, span, m)
+	assert.Equal(t, "5", span.GRPCCode)
+	assert.Equal(t, "NOT_FOUND", span.GRPCStatusName)
+}
+
+func TestParseGRPCStatusCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  pdata.AttributeValue
+		want   int64
+		wantOk bool
+	}{
+		{name: "int", value: pdata.NewAttributeValueInt(14), want: 14, wantOk: true},
+		{name: "double", value: pdata.NewAttributeValueDouble(14), want: 14, wantOk: true},
+		{name: "numeric string", value: pdata.NewAttributeValueString("14"), want: 14, wantOk: true},
+		{name: "zero string", value: pdata.NewAttributeValueString("0"), want: 0, wantOk: true},
+		{name: "name", value: pdata.NewAttributeValueString("UNAVAILABLE"), want: 14, wantOk: true},
+		{name: "lower case name", value: pdata.NewAttributeValueString("unavailable"), want: 14, wantOk: true},
+		{name: "qualified name", value: pdata.NewAttributeValueString("StatusCode.UNAVAILABLE"), want: 14, wantOk: true},
+		{name: "unknown name", value: pdata.NewAttributeValueString("BROKEN"), wantOk: false},
+		{name: "bool", value: pdata.NewAttributeValueBool(true), wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, ok := parseGRPCStatusCode(tt.value)
+			assert.Equal(t, tt.wantOk, ok)
+			if tt.wantOk {
+				assert.Equal(t, tt.want, code)
+			}
+		})
+	}
+}
+
+func TestPopulateOtherDimensionsUnknownGRPCStatus(t *testing.T) {
+	m, err := NewErrorMatcher(HasErrorSettings{})
+	require.NoError(t, err)
+
+	attributes := pdata.NewAttributeMap()
+	attributes.InsertString("rpc.grpc.status_code", "BROKEN")
+	span := &Span{}
+	populateOtherDimensions(attributes, span, m)
+	assert.Empty(t, span.GRPCCode)
+	assert.Empty(t, span.ResponseStatusCode)
+	assert.False(t, span.HasError)
+}
@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signoz
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// HasErrorSettings configures which spans are flagged with hasError besides
+// the ones with an error status.
+type HasErrorSettings struct {
+	// HTTPStatusCodes are the http.status_code values flagging a span, either
+	// single codes such as "429" or inclusive ranges such as "500-599". Every
+	// code of 400 and above flags the span when empty.
+	HTTPStatusCodes []string `mapstructure:"http_status_codes"`
+
+	// Rules flag a span when any of them matches its attributes, for
+	// instrumentations that signal errors through attributes of their own.
+	Rules []HasErrorRule `mapstructure:"rules"`
+}
+
+// HasErrorRule compares a span attribute with a value.
+type HasErrorRule struct {
+	// Attribute is the key of the span attribute.
+	Attribute string `mapstructure:"attribute"`
+	// Operator is one of "exists", "==", "!=", ">", ">=", "<" and "<=".
+	// The ordering operators compare numerically.
+	Operator string `mapstructure:"operator"`
+	// Value is compared with the attribute, it is ignored by "exists".
+	Value string `mapstructure:"value"`
+}
+
+type statusCodeRange struct {
+	min, max int64
+}
+
+type errorRule struct {
+	HasErrorRule
+	number float64
+}
+
+// ErrorMatcher decides from span attributes whether a span is flagged with hasError.
+type ErrorMatcher struct {
+	httpStatusCodes []statusCodeRange
+	rules           []errorRule
+}
+
+// NewErrorMatcher returns the matcher of settings, or an error if they are invalid.
+func NewErrorMatcher(settings HasErrorSettings) (*ErrorMatcher, error) {
+	m := &ErrorMatcher{}
+	if len(settings.HTTPStatusCodes) == 0 {
+		m.httpStatusCodes = []statusCodeRange{{min: 400, max: math.MaxInt64}}
+	}
+	for _, codes := range settings.HTTPStatusCodes {
+		r, err := parseStatusCodeRange(codes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid has_error::http_status_codes entry %q: %w", codes, err)
+		}
+		m.httpStatusCodes = append(m.httpStatusCodes, r)
+	}
+	for i, rule := range settings.Rules {
+		r, err := newErrorRule(rule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid has_error::rules[%d]: %w", i, err)
+		}
+		m.rules = append(m.rules, r)
+	}
+	return m, nil
+}
+
+func newErrorRule(rule HasErrorRule) (errorRule, error) {
+	if rule.Attribute == "" {
+		return errorRule{}, errors.New("attribute must not be empty")
+	}
+	r := errorRule{HasErrorRule: rule}
+	switch rule.Operator {
+	case "exists", "==", "!=":
+	case ">", ">=", "<", "<=":
+		number, err := strconv.ParseFloat(rule.Value, 64)
+		if err != nil {
+			return errorRule{}, fmt.Errorf("operator %s needs a numeric value: %w", rule.Operator, err)
+		}
+		r.number = number
+	default:
+		return errorRule{}, fmt.Errorf("unknown operator %q", rule.Operator)
+	}
+	return r, nil
+}
+
+func parseStatusCodeRange(s string) (statusCodeRange, error) {
+	from, to := s, s
+	if i := strings.Index(s, "-"); i >= 0 {
+		from, to = s[:i], s[i+1:]
+	}
+	min, err := strconv.ParseInt(strings.TrimSpace(from), 10, 64)
+	if err != nil {
+		return statusCodeRange{}, err
+	}
+	max, err := strconv.ParseInt(strings.TrimSpace(to), 10, 64)
+	if err != nil {
+		return statusCodeRange{}, err
+	}
+	if min > max {
+		return statusCodeRange{}, fmt.Errorf("%d is greater than %d", min, max)
+	}
+	return statusCodeRange{min: min, max: max}, nil
+}
+
+func (m *ErrorMatcher) isHTTPError(code int64) bool {
+	for _, r := range m.httpStatusCodes {
+		if code >= r.min && code <= r.max {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesRules reports whether any rule matches the attributes.
+func (m *ErrorMatcher) matchesRules(attributes pdata.AttributeMap) bool {
+	for _, r := range m.rules {
+		v, ok := attributes.Get(r.Attribute)
+		if ok && r.matches(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r errorRule) matches(v pdata.AttributeValue) bool {
+	switch r.Operator {
+	case "exists":
+		return true
+	case "==":
+		return v.AsString() == r.Value
+	case "!=":
+		return v.AsString() != r.Value
+	}
+
+	var number float64
+	switch v.Type() {
+	case pdata.AttributeValueTypeInt:
+		number = float64(v.IntVal())
+	case pdata.AttributeValueTypeDouble:
+		number = v.DoubleVal()
+	default:
+		var err error
+		if number, err = strconv.ParseFloat(v.AsString(), 64); err != nil {
+			return false
+		}
+	}
+	switch r.Operator {
+	case ">":
+		return number > r.number
+	case ">=":
+		return number >= r.number
+	case "<":
+		return number < r.number
+	default:
+		return number <= r.number
+	}
+}
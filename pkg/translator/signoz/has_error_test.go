@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signoz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestErrorMatcherHTTPStatusCodes(t *testing.T) {
+	m, err := NewErrorMatcher(HasErrorSettings{})
+	require.NoError(t, err)
+	assert.False(t, m.isHTTPError(399))
+	assert.True(t, m.isHTTPError(404))
+	assert.True(t, m.isHTTPError(503))
+
+	m, err = NewErrorMatcher(HasErrorSettings{HTTPStatusCodes: []string{"429", "500-599"}})
+	require.NoError(t, err)
+	assert.False(t, m.isHTTPError(404))
+	assert.True(t, m.isHTTPError(429))
+	assert.True(t, m.isHTTPError(503))
+	assert.False(t, m.isHTTPError(600))
+
+	_, err = NewErrorMatcher(HasErrorSettings{HTTPStatusCodes: []string{"599-500"}})
+	assert.EqualError(t, err, `invalid has_error::http_status_codes entry "599-500": 599 is greater than 500`)
+	_, err = NewErrorMatcher(HasErrorSettings{HTTPStatusCodes: []string{"5xx"}})
+	assert.Error(t, err)
+}
+
+func TestPopulateOtherDimensionsHTTPStatusCode(t *testing.T) {
+	m, err := NewErrorMatcher(HasErrorSettings{HTTPStatusCodes: []string{"500-599"}})
+	require.NoError(t, err)
+
+	attributes := pdata.NewAttributeMap()
+	attributes.InsertInt("http.status_code", 404)
+	span := &Span{}
+	populateOtherDimensions(attributes, span, m)
+	assert.False(t, span.HasError)
+	assert.Equal(t, "404", span.HttpCode)
+
+	attributes.UpdateInt("http.status_code", 502)
+	populateOtherDimensions(attributes, span, m)
+	assert.True(t, span.HasError)
+}
+
+func TestPopulateOtherDimensionsHTTPStatusCodeTypes(t *testing.T) {
+	m, err := NewErrorMatcher(HasErrorSettings{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		key   string
+		value pdata.AttributeValue
+		want  string
+	}{
+		{name: "int", key: "http.status_code", value: pdata.NewAttributeValueInt(503), want: "503"},
+		{name: "double", key: "http.status_code", value: pdata.NewAttributeValueDouble(503), want: "503"},
+		{name: "string", key: "http.status_code", value: pdata.NewAttributeValueString("503"), want: "503"},
+		{name: "stable semconv", key: "http.response.status_code", value: pdata.NewAttributeValueInt(503), want: "503"},
+		{name: "invalid", key: "http.status_code", value: pdata.NewAttributeValueString("n/a"), want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attributes := pdata.NewAttributeMap()
+			attributes.Insert(tt.key, tt.value)
+			span := &Span{}
+			populateOtherDimensions(attributes, span, m)
+			assert.Equal(t, tt.want, span.HttpCode)
+			assert.Equal(t, tt.want != "", span.HasError)
+		})
+	}
+}
+
+func TestErrorMatcherRules(t *testing.T) {
+	m, err := NewErrorMatcher(HasErrorSettings{Rules: []HasErrorRule{
+		{Attribute: "error", Operator: "==", Value: "true"},
+		{Attribute: "graphql.errors.count", Operator: ">", Value: "0"},
+	}})
+	require.NoError(t, err)
+
+	attributes := pdata.NewAttributeMap()
+	assert.False(t, m.matchesRules(attributes))
+	attributes.InsertInt("graphql.errors.count", 0)
+	assert.False(t, m.matchesRules(attributes))
+	attributes.UpdateInt("graphql.errors.count", 2)
+	assert.True(t, m.matchesRules(attributes))
+
+	attributes = pdata.NewAttributeMap()
+	attributes.InsertBool("error", true)
+	assert.True(t, m.matchesRules(attributes))
+
+	_, err = NewErrorMatcher(HasErrorSettings{Rules: []HasErrorRule{{Attribute: "error", Operator: "~"}}})
+	assert.EqualError(t, err, `invalid has_error::rules[0]: unknown operator "~"`)
+	_, err = NewErrorMatcher(HasErrorSettings{Rules: []HasErrorRule{{Attribute: "retries", Operator: ">", Value: "many"}}})
+	assert.Error(t, err)
+}
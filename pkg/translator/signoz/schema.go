@@ -0,0 +1,127 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate go run github.com/mailru/easyjson/easyjson -all -no_std_marshalers schema.go
+
+package signoz
+
+type Event struct {
+	Name         string            `json:"name,omitempty"`
+	TimeUnixNano uint64            `json:"timeUnixNano,omitempty"`
+	AttributeMap map[string]string `json:"attributeMap,omitempty"`
+	IsError      bool              `json:"isError,omitempty"`
+}
+
+type TraceModel struct {
+	TraceId           string            `json:"traceId,omitempty"`
+	SpanId            string            `json:"spanId,omitempty"`
+	Name              string            `json:"name,omitempty"`
+	DurationNano      uint64            `json:"durationNano,omitempty"`
+	StartTimeUnixNano uint64            `json:"startTimeUnixNano,omitempty"`
+	ServiceName       string            `json:"serviceName,omitempty"`
+	Kind              int8              `json:"kind,omitempty"`
+	References        []OtelSpanRef     `json:"references,omitempty"`
+	StatusCode        int16             `json:"statusCode,omitempty"`
+	TagMap            map[string]string `json:"tagMap,omitempty"`
+	Events            []string          `json:"event,omitempty"`
+	HasError          bool              `json:"hasError,omitempty"`
+}
+
+type Span struct {
+	TraceId               string            `json:"traceId,omitempty"`
+	SpanId                string            `json:"spanId,omitempty"`
+	ParentSpanId          string            `json:"parentSpanId,omitempty"`
+	Name                  string            `json:"name,omitempty"`
+	DurationNano          uint64            `json:"durationNano,omitempty"`
+	StartTimeUnixNano     uint64            `json:"startTimeUnixNano,omitempty"`
+	ServiceName           string            `json:"serviceName,omitempty"`
+	Kind                  int8              `json:"kind,omitempty"`
+	StatusCode            int16             `json:"statusCode,omitempty"`
+	ExternalHttpMethod    string            `json:"externalHttpMethod,omitempty"`
+	HttpUrl               string            `json:"httpUrl,omitempty"`
+	HttpMethod            string            `json:"httpMethod,omitempty"`
+	HttpHost              string            `json:"httpHost,omitempty"`
+	HttpRoute             string            `json:"httpRoute,omitempty"`
+	HttpCode              string            `json:"httpCode,omitempty"`
+	MsgSystem             string            `json:"msgSystem,omitempty"`
+	MsgOperation          string            `json:"msgOperation,omitempty"`
+	ExternalHttpUrl       string            `json:"externalHttpUrl,omitempty"`
+	Component             string            `json:"component,omitempty"`
+	DBSystem              string            `json:"dbSystem,omitempty"`
+	DBName                string            `json:"dbName,omitempty"`
+	DBOperation           string            `json:"dbOperation,omitempty"`
+	PeerService           string            `json:"peerService,omitempty"`
+	Events                []string          `json:"event,omitempty"`
+	ErrorEvent            Event             `json:"errorEvent,omitempty"`
+	ErrorID               string            `json:"errorID,omitempty"`
+	ErrorGroupID          string            `json:"errorGroupID,omitempty"`
+	ErrorEscaped          bool              `json:"errorEscaped,omitempty"`
+	ErrorDropped          uint64            `json:"errorDropped,omitempty"`
+	TagMap                map[string]string `json:"tagMap,omitempty"`
+	HasError              bool              `json:"hasError,omitempty"`
+	TraceModel            TraceModel        `json:"traceModel,omitempty"`
+	GRPCCode              string            `json:"gRPCCode,omitempty"`
+	GRPCMethod            string            `json:"gRPCMethod,omitempty"`
+	RPCSystem             string            `json:"rpcSystem,omitempty"`
+	RPCService            string            `json:"rpcService,omitempty"`
+	RPCMethod             string            `json:"rpcMethod,omitempty"`
+	ResponseStatusCode    string            `json:"responseStatusCode,omitempty"`
+	GRPCStatusName        string            `json:"gRPCStatusName,omitempty"`
+	HttpPath              string            `json:"httpPath,omitempty"`
+	Team                  string            `json:"team,omitempty"`
+	Owner                 string            `json:"owner,omitempty"`
+	Tier                  string            `json:"tier,omitempty"`
+	HttpRequestSize       uint64            `json:"httpRequestContentLength,omitempty"`
+	HttpResponseSize      uint64            `json:"httpResponseContentLength,omitempty"`
+	ProfileID             string            `json:"profileID,omitempty"`
+	CollectorHostname     string            `json:"collectorHostname,omitempty"`
+	CollectorInstanceID   string            `json:"collectorInstanceId,omitempty"`
+	K8sNamespaceName      string            `json:"k8sNamespaceName,omitempty"`
+	K8sPodName            string            `json:"k8sPodName,omitempty"`
+	K8sDeploymentName     string            `json:"k8sDeploymentName,omitempty"`
+	K8sNodeName           string            `json:"k8sNodeName,omitempty"`
+	CloudProvider         string            `json:"cloudProvider,omitempty"`
+	CloudRegion           string            `json:"cloudRegion,omitempty"`
+	CloudAvailabilityZone string            `json:"cloudAvailabilityZone,omitempty"`
+	CloudAccountID        string            `json:"cloudAccountId,omitempty"`
+	ContainerID           string            `json:"containerId,omitempty"`
+	ContainerImageName    string            `json:"containerImageName,omitempty"`
+	ContainerImageTag     string            `json:"containerImageTag,omitempty"`
+	HostName              string            `json:"hostName,omitempty"`
+	OSType                string            `json:"osType,omitempty"`
+	SpanHash              uint64            `json:"spanHash,omitempty"`
+	LateArrival           bool              `json:"lateArrival,omitempty"`
+	// OriginalStartTimeUnixNano is the start time reported by the client when it was
+	// corrected for clock skew, zero otherwise.
+	OriginalStartTimeUnixNano uint64 `json:"originalStartTimeUnixNano,omitempty"`
+	// SpanEvents are the events of the span for exporters writing one row per event.
+	// Events holds them serialized for the index row.
+	SpanEvents []Event `json:"-"`
+	// SpanLinks are the links of the span for exporters writing one row per link.
+	SpanLinks []Link `json:"-"`
+}
+
+// Link is a link from a span to another span.
+type Link struct {
+	TraceId      string            `json:"traceId,omitempty"`
+	SpanId       string            `json:"spanId,omitempty"`
+	TraceState   string            `json:"traceState,omitempty"`
+	AttributeMap map[string]string `json:"attributeMap,omitempty"`
+}
+
+type OtelSpanRef struct {
+	TraceId string `json:"traceId,omitempty"`
+	SpanId  string `json:"spanId,omitempty"`
+	RefType string `json:"refType,omitempty"`
+}
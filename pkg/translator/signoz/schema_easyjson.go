@@ -0,0 +1,1486 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package signoz
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonCef4e921DecodeGithubComOpenTelemetryOpentelemetryCollectorContribPkgTranslatorSignoz(in *jlexer.Lexer, out *TraceModel) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "traceId":
+			out.TraceId = string(in.String())
+		case "spanId":
+			out.SpanId = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "durationNano":
+			out.DurationNano = uint64(in.Uint64())
+		case "startTimeUnixNano":
+			out.StartTimeUnixNano = uint64(in.Uint64())
+		case "serviceName":
+			out.ServiceName = string(in.String())
+		case "kind":
+			out.Kind = int8(in.Int8())
+		case "references":
+			if in.IsNull() {
+				in.Skip()
+				out.References = nil
+			} else {
+				in.Delim('[')
+				if out.References == nil {
+					if !in.IsDelim(']') {
+						out.References = make([]OtelSpanRef, 0, 1)
+					} else {
+						out.References = []OtelSpanRef{}
+					}
+				} else {
+					out.References = (out.References)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 OtelSpanRef
+					(v1).UnmarshalEasyJSON(in)
+					out.References = append(out.References, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "statusCode":
+			out.StatusCode = int16(in.Int16())
+		case "tagMap":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.TagMap = make(map[string]string)
+				} else {
+					out.TagMap = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v2 string
+					v2 = string(in.String())
+					(out.TagMap)[key] = v2
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "event":
+			if in.IsNull() {
+				in.Skip()
+				out.Events = nil
+			} else {
+				in.Delim('[')
+				if out.Events == nil {
+					if !in.IsDelim(']') {
+						out.Events = make([]string, 0, 4)
+					} else {
+						out.Events = []string{}
+					}
+				} else {
+					out.Events = (out.Events)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v3 string
+					v3 = string(in.String())
+					out.Events = append(out.Events, v3)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "hasError":
+			out.HasError = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonCef4e921EncodeGithubComOpenTelemetryOpentelemetryCollectorContribPkgTranslatorSignoz(out *jwriter.Writer, in TraceModel) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.TraceId != "" {
+		const prefix string = ",\"traceId\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.TraceId))
+	}
+	if in.SpanId != "" {
+		const prefix string = ",\"spanId\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.SpanId))
+	}
+	if in.Name != "" {
+		const prefix string = ",\"name\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Name))
+	}
+	if in.DurationNano != 0 {
+		const prefix string = ",\"durationNano\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.DurationNano))
+	}
+	if in.StartTimeUnixNano != 0 {
+		const prefix string = ",\"startTimeUnixNano\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.StartTimeUnixNano))
+	}
+	if in.ServiceName != "" {
+		const prefix string = ",\"serviceName\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ServiceName))
+	}
+	if in.Kind != 0 {
+		const prefix string = ",\"kind\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int8(int8(in.Kind))
+	}
+	if len(in.References) != 0 {
+		const prefix string = ",\"references\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v4, v5 := range in.References {
+				if v4 > 0 {
+					out.RawByte(',')
+				}
+				(v5).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.StatusCode != 0 {
+		const prefix string = ",\"statusCode\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int16(int16(in.StatusCode))
+	}
+	if len(in.TagMap) != 0 {
+		const prefix string = ",\"tagMap\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v6First := true
+			for v6Name, v6Value := range in.TagMap {
+				if v6First {
+					v6First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v6Name))
+				out.RawByte(':')
+				out.String(string(v6Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	if len(in.Events) != 0 {
+		const prefix string = ",\"event\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v7, v8 := range in.Events {
+				if v7 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v8))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.HasError {
+		const prefix string = ",\"hasError\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.HasError))
+	}
+	out.RawByte('}')
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TraceModel) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonCef4e921EncodeGithubComOpenTelemetryOpentelemetryCollectorContribPkgTranslatorSignoz(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TraceModel) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonCef4e921DecodeGithubComOpenTelemetryOpentelemetryCollectorContribPkgTranslatorSignoz(l, v)
+}
+func easyjsonCef4e921DecodeGithubComOpenTelemetryOpentelemetryCollectorContribPkgTranslatorSignoz1(in *jlexer.Lexer, out *Span) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "traceId":
+			out.TraceId = string(in.String())
+		case "spanId":
+			out.SpanId = string(in.String())
+		case "parentSpanId":
+			out.ParentSpanId = string(in.String())
+		case "name":
+			out.Name = string(in.String())
+		case "durationNano":
+			out.DurationNano = uint64(in.Uint64())
+		case "startTimeUnixNano":
+			out.StartTimeUnixNano = uint64(in.Uint64())
+		case "serviceName":
+			out.ServiceName = string(in.String())
+		case "kind":
+			out.Kind = int8(in.Int8())
+		case "statusCode":
+			out.StatusCode = int16(in.Int16())
+		case "externalHttpMethod":
+			out.ExternalHttpMethod = string(in.String())
+		case "httpUrl":
+			out.HttpUrl = string(in.String())
+		case "httpMethod":
+			out.HttpMethod = string(in.String())
+		case "httpHost":
+			out.HttpHost = string(in.String())
+		case "httpRoute":
+			out.HttpRoute = string(in.String())
+		case "httpCode":
+			out.HttpCode = string(in.String())
+		case "msgSystem":
+			out.MsgSystem = string(in.String())
+		case "msgOperation":
+			out.MsgOperation = string(in.String())
+		case "externalHttpUrl":
+			out.ExternalHttpUrl = string(in.String())
+		case "component":
+			out.Component = string(in.String())
+		case "dbSystem":
+			out.DBSystem = string(in.String())
+		case "dbName":
+			out.DBName = string(in.String())
+		case "dbOperation":
+			out.DBOperation = string(in.String())
+		case "peerService":
+			out.PeerService = string(in.String())
+		case "event":
+			if in.IsNull() {
+				in.Skip()
+				out.Events = nil
+			} else {
+				in.Delim('[')
+				if out.Events == nil {
+					if !in.IsDelim(']') {
+						out.Events = make([]string, 0, 4)
+					} else {
+						out.Events = []string{}
+					}
+				} else {
+					out.Events = (out.Events)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v9 string
+					v9 = string(in.String())
+					out.Events = append(out.Events, v9)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "errorEvent":
+			(out.ErrorEvent).UnmarshalEasyJSON(in)
+		case "errorID":
+			out.ErrorID = string(in.String())
+		case "errorGroupID":
+			out.ErrorGroupID = string(in.String())
+		case "errorEscaped":
+			out.ErrorEscaped = bool(in.Bool())
+		case "errorDropped":
+			out.ErrorDropped = uint64(in.Uint64())
+		case "tagMap":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.TagMap = make(map[string]string)
+				} else {
+					out.TagMap = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v10 string
+					v10 = string(in.String())
+					(out.TagMap)[key] = v10
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "hasError":
+			out.HasError = bool(in.Bool())
+		case "traceModel":
+			(out.TraceModel).UnmarshalEasyJSON(in)
+		case "gRPCCode":
+			out.GRPCCode = string(in.String())
+		case "gRPCMethod":
+			out.GRPCMethod = string(in.String())
+		case "rpcSystem":
+			out.RPCSystem = string(in.String())
+		case "rpcService":
+			out.RPCService = string(in.String())
+		case "rpcMethod":
+			out.RPCMethod = string(in.String())
+		case "responseStatusCode":
+			out.ResponseStatusCode = string(in.String())
+		case "gRPCStatusName":
+			out.GRPCStatusName = string(in.String())
+		case "httpPath":
+			out.HttpPath = string(in.String())
+		case "team":
+			out.Team = string(in.String())
+		case "owner":
+			out.Owner = string(in.String())
+		case "tier":
+			out.Tier = string(in.String())
+		case "httpRequestContentLength":
+			out.HttpRequestSize = uint64(in.Uint64())
+		case "httpResponseContentLength":
+			out.HttpResponseSize = uint64(in.Uint64())
+		case "profileID":
+			out.ProfileID = string(in.String())
+		case "collectorHostname":
+			out.CollectorHostname = string(in.String())
+		case "collectorInstanceId":
+			out.CollectorInstanceID = string(in.String())
+		case "k8sNamespaceName":
+			out.K8sNamespaceName = string(in.String())
+		case "k8sPodName":
+			out.K8sPodName = string(in.String())
+		case "k8sDeploymentName":
+			out.K8sDeploymentName = string(in.String())
+		case "k8sNodeName":
+			out.K8sNodeName = string(in.String())
+		case "cloudProvider":
+			out.CloudProvider = string(in.String())
+		case "cloudRegion":
+			out.CloudRegion = string(in.String())
+		case "cloudAvailabilityZone":
+			out.CloudAvailabilityZone = string(in.String())
+		case "cloudAccountId":
+			out.CloudAccountID = string(in.String())
+		case "containerId":
+			out.ContainerID = string(in.String())
+		case "containerImageName":
+			out.ContainerImageName = string(in.String())
+		case "containerImageTag":
+			out.ContainerImageTag = string(in.String())
+		case "hostName":
+			out.HostName = string(in.String())
+		case "osType":
+			out.OSType = string(in.String())
+		case "spanHash":
+			out.SpanHash = uint64(in.Uint64())
+		case "lateArrival":
+			out.LateArrival = bool(in.Bool())
+		case "originalStartTimeUnixNano":
+			out.OriginalStartTimeUnixNano = uint64(in.Uint64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonCef4e921EncodeGithubComOpenTelemetryOpentelemetryCollectorContribPkgTranslatorSignoz1(out *jwriter.Writer, in Span) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.TraceId != "" {
+		const prefix string = ",\"traceId\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.TraceId))
+	}
+	if in.SpanId != "" {
+		const prefix string = ",\"spanId\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.SpanId))
+	}
+	if in.ParentSpanId != "" {
+		const prefix string = ",\"parentSpanId\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ParentSpanId))
+	}
+	if in.Name != "" {
+		const prefix string = ",\"name\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Name))
+	}
+	if in.DurationNano != 0 {
+		const prefix string = ",\"durationNano\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.DurationNano))
+	}
+	if in.StartTimeUnixNano != 0 {
+		const prefix string = ",\"startTimeUnixNano\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.StartTimeUnixNano))
+	}
+	if in.ServiceName != "" {
+		const prefix string = ",\"serviceName\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ServiceName))
+	}
+	if in.Kind != 0 {
+		const prefix string = ",\"kind\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int8(int8(in.Kind))
+	}
+	if in.StatusCode != 0 {
+		const prefix string = ",\"statusCode\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int16(int16(in.StatusCode))
+	}
+	if in.ExternalHttpMethod != "" {
+		const prefix string = ",\"externalHttpMethod\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ExternalHttpMethod))
+	}
+	if in.HttpUrl != "" {
+		const prefix string = ",\"httpUrl\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.HttpUrl))
+	}
+	if in.HttpMethod != "" {
+		const prefix string = ",\"httpMethod\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.HttpMethod))
+	}
+	if in.HttpHost != "" {
+		const prefix string = ",\"httpHost\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.HttpHost))
+	}
+	if in.HttpRoute != "" {
+		const prefix string = ",\"httpRoute\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.HttpRoute))
+	}
+	if in.HttpCode != "" {
+		const prefix string = ",\"httpCode\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.HttpCode))
+	}
+	if in.MsgSystem != "" {
+		const prefix string = ",\"msgSystem\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.MsgSystem))
+	}
+	if in.MsgOperation != "" {
+		const prefix string = ",\"msgOperation\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.MsgOperation))
+	}
+	if in.ExternalHttpUrl != "" {
+		const prefix string = ",\"externalHttpUrl\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ExternalHttpUrl))
+	}
+	if in.Component != "" {
+		const prefix string = ",\"component\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Component))
+	}
+	if in.DBSystem != "" {
+		const prefix string = ",\"dbSystem\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.DBSystem))
+	}
+	if in.DBName != "" {
+		const prefix string = ",\"dbName\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.DBName))
+	}
+	if in.DBOperation != "" {
+		const prefix string = ",\"dbOperation\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.DBOperation))
+	}
+	if in.PeerService != "" {
+		const prefix string = ",\"peerService\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.PeerService))
+	}
+	if len(in.Events) != 0 {
+		const prefix string = ",\"event\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v11, v12 := range in.Events {
+				if v11 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v12))
+			}
+			out.RawByte(']')
+		}
+	}
+	if true {
+		const prefix string = ",\"errorEvent\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(in.ErrorEvent).MarshalEasyJSON(out)
+	}
+	if in.ErrorID != "" {
+		const prefix string = ",\"errorID\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ErrorID))
+	}
+	if in.ErrorGroupID != "" {
+		const prefix string = ",\"errorGroupID\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ErrorGroupID))
+	}
+	if in.ErrorEscaped {
+		const prefix string = ",\"errorEscaped\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.ErrorEscaped))
+	}
+	if in.ErrorDropped != 0 {
+		const prefix string = ",\"errorDropped\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.ErrorDropped))
+	}
+	if len(in.TagMap) != 0 {
+		const prefix string = ",\"tagMap\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v13First := true
+			for v13Name, v13Value := range in.TagMap {
+				if v13First {
+					v13First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v13Name))
+				out.RawByte(':')
+				out.String(string(v13Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	if in.HasError {
+		const prefix string = ",\"hasError\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.HasError))
+	}
+	if true {
+		const prefix string = ",\"traceModel\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(in.TraceModel).MarshalEasyJSON(out)
+	}
+	if in.GRPCCode != "" {
+		const prefix string = ",\"gRPCCode\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.GRPCCode))
+	}
+	if in.GRPCMethod != "" {
+		const prefix string = ",\"gRPCMethod\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.GRPCMethod))
+	}
+	if in.RPCSystem != "" {
+		const prefix string = ",\"rpcSystem\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.RPCSystem))
+	}
+	if in.RPCService != "" {
+		const prefix string = ",\"rpcService\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.RPCService))
+	}
+	if in.RPCMethod != "" {
+		const prefix string = ",\"rpcMethod\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.RPCMethod))
+	}
+	if in.ResponseStatusCode != "" {
+		const prefix string = ",\"responseStatusCode\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ResponseStatusCode))
+	}
+	if in.GRPCStatusName != "" {
+		const prefix string = ",\"gRPCStatusName\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.GRPCStatusName))
+	}
+	if in.HttpPath != "" {
+		const prefix string = ",\"httpPath\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.HttpPath))
+	}
+	if in.Team != "" {
+		const prefix string = ",\"team\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Team))
+	}
+	if in.Owner != "" {
+		const prefix string = ",\"owner\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Owner))
+	}
+	if in.Tier != "" {
+		const prefix string = ",\"tier\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Tier))
+	}
+	if in.HttpRequestSize != 0 {
+		const prefix string = ",\"httpRequestContentLength\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.HttpRequestSize))
+	}
+	if in.HttpResponseSize != 0 {
+		const prefix string = ",\"httpResponseContentLength\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.HttpResponseSize))
+	}
+	if in.ProfileID != "" {
+		const prefix string = ",\"profileID\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ProfileID))
+	}
+	if in.CollectorHostname != "" {
+		const prefix string = ",\"collectorHostname\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.CollectorHostname))
+	}
+	if in.CollectorInstanceID != "" {
+		const prefix string = ",\"collectorInstanceId\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.CollectorInstanceID))
+	}
+	if in.K8sNamespaceName != "" {
+		const prefix string = ",\"k8sNamespaceName\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.K8sNamespaceName))
+	}
+	if in.K8sPodName != "" {
+		const prefix string = ",\"k8sPodName\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.K8sPodName))
+	}
+	if in.K8sDeploymentName != "" {
+		const prefix string = ",\"k8sDeploymentName\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.K8sDeploymentName))
+	}
+	if in.K8sNodeName != "" {
+		const prefix string = ",\"k8sNodeName\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.K8sNodeName))
+	}
+	if in.CloudProvider != "" {
+		const prefix string = ",\"cloudProvider\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.CloudProvider))
+	}
+	if in.CloudRegion != "" {
+		const prefix string = ",\"cloudRegion\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.CloudRegion))
+	}
+	if in.CloudAvailabilityZone != "" {
+		const prefix string = ",\"cloudAvailabilityZone\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.CloudAvailabilityZone))
+	}
+	if in.CloudAccountID != "" {
+		const prefix string = ",\"cloudAccountId\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.CloudAccountID))
+	}
+	if in.ContainerID != "" {
+		const prefix string = ",\"containerId\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ContainerID))
+	}
+	if in.ContainerImageName != "" {
+		const prefix string = ",\"containerImageName\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ContainerImageName))
+	}
+	if in.ContainerImageTag != "" {
+		const prefix string = ",\"containerImageTag\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ContainerImageTag))
+	}
+	if in.HostName != "" {
+		const prefix string = ",\"hostName\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.HostName))
+	}
+	if in.OSType != "" {
+		const prefix string = ",\"osType\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.OSType))
+	}
+	if in.SpanHash != 0 {
+		const prefix string = ",\"spanHash\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.SpanHash))
+	}
+	if in.LateArrival {
+		const prefix string = ",\"lateArrival\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.LateArrival))
+	}
+	if in.OriginalStartTimeUnixNano != 0 {
+		const prefix string = ",\"originalStartTimeUnixNano\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.OriginalStartTimeUnixNano))
+	}
+	out.RawByte('}')
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Span) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonCef4e921EncodeGithubComOpenTelemetryOpentelemetryCollectorContribPkgTranslatorSignoz1(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Span) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonCef4e921DecodeGithubComOpenTelemetryOpentelemetryCollectorContribPkgTranslatorSignoz1(l, v)
+}
+func easyjsonCef4e921DecodeGithubComOpenTelemetryOpentelemetryCollectorContribPkgTranslatorSignoz2(in *jlexer.Lexer, out *OtelSpanRef) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "traceId":
+			out.TraceId = string(in.String())
+		case "spanId":
+			out.SpanId = string(in.String())
+		case "refType":
+			out.RefType = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonCef4e921EncodeGithubComOpenTelemetryOpentelemetryCollectorContribPkgTranslatorSignoz2(out *jwriter.Writer, in OtelSpanRef) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.TraceId != "" {
+		const prefix string = ",\"traceId\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.TraceId))
+	}
+	if in.SpanId != "" {
+		const prefix string = ",\"spanId\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.SpanId))
+	}
+	if in.RefType != "" {
+		const prefix string = ",\"refType\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.RefType))
+	}
+	out.RawByte('}')
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v OtelSpanRef) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonCef4e921EncodeGithubComOpenTelemetryOpentelemetryCollectorContribPkgTranslatorSignoz2(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *OtelSpanRef) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonCef4e921DecodeGithubComOpenTelemetryOpentelemetryCollectorContribPkgTranslatorSignoz2(l, v)
+}
+func easyjsonCef4e921DecodeGithubComOpenTelemetryOpentelemetryCollectorContribPkgTranslatorSignoz3(in *jlexer.Lexer, out *Link) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "traceId":
+			out.TraceId = string(in.String())
+		case "spanId":
+			out.SpanId = string(in.String())
+		case "traceState":
+			out.TraceState = string(in.String())
+		case "attributeMap":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.AttributeMap = make(map[string]string)
+				} else {
+					out.AttributeMap = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v14 string
+					v14 = string(in.String())
+					(out.AttributeMap)[key] = v14
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonCef4e921EncodeGithubComOpenTelemetryOpentelemetryCollectorContribPkgTranslatorSignoz3(out *jwriter.Writer, in Link) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.TraceId != "" {
+		const prefix string = ",\"traceId\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.TraceId))
+	}
+	if in.SpanId != "" {
+		const prefix string = ",\"spanId\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.SpanId))
+	}
+	if in.TraceState != "" {
+		const prefix string = ",\"traceState\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.TraceState))
+	}
+	if len(in.AttributeMap) != 0 {
+		const prefix string = ",\"attributeMap\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v15First := true
+			for v15Name, v15Value := range in.AttributeMap {
+				if v15First {
+					v15First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v15Name))
+				out.RawByte(':')
+				out.String(string(v15Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Link) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonCef4e921EncodeGithubComOpenTelemetryOpentelemetryCollectorContribPkgTranslatorSignoz3(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Link) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonCef4e921DecodeGithubComOpenTelemetryOpentelemetryCollectorContribPkgTranslatorSignoz3(l, v)
+}
+func easyjsonCef4e921DecodeGithubComOpenTelemetryOpentelemetryCollectorContribPkgTranslatorSignoz4(in *jlexer.Lexer, out *Event) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "timeUnixNano":
+			out.TimeUnixNano = uint64(in.Uint64())
+		case "attributeMap":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.AttributeMap = make(map[string]string)
+				} else {
+					out.AttributeMap = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v16 string
+					v16 = string(in.String())
+					(out.AttributeMap)[key] = v16
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "isError":
+			out.IsError = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonCef4e921EncodeGithubComOpenTelemetryOpentelemetryCollectorContribPkgTranslatorSignoz4(out *jwriter.Writer, in Event) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Name != "" {
+		const prefix string = ",\"name\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	if in.TimeUnixNano != 0 {
+		const prefix string = ",\"timeUnixNano\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Uint64(uint64(in.TimeUnixNano))
+	}
+	if len(in.AttributeMap) != 0 {
+		const prefix string = ",\"attributeMap\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('{')
+			v17First := true
+			for v17Name, v17Value := range in.AttributeMap {
+				if v17First {
+					v17First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v17Name))
+				out.RawByte(':')
+				out.String(string(v17Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	if in.IsError {
+		const prefix string = ",\"isError\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.IsError))
+	}
+	out.RawByte('}')
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Event) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonCef4e921EncodeGithubComOpenTelemetryOpentelemetryCollectorContribPkgTranslatorSignoz4(w, v)
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Event) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonCef4e921DecodeGithubComOpenTelemetryOpentelemetryCollectorContribPkgTranslatorSignoz4(l, v)
+}
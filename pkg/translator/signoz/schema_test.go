@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signoz
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mailru/easyjson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTraceModel() TraceModel {
+	return TraceModel{
+		TraceId:           "0102030405060708090a0b0c0d0e0f10",
+		SpanId:            "0102030405060708",
+		Name:              "GET /orders/<id>",
+		DurationNano:      1500000,
+		StartTimeUnixNano: 1650000000000000000,
+		ServiceName:       "frontend",
+		Kind:              2,
+		References:        []OtelSpanRef{{TraceId: "0102030405060708090a0b0c0d0e0f10", SpanId: "0807060504030201", RefType: "CHILD_OF"}},
+		StatusCode:        2,
+		TagMap: map[string]string{
+			"http.url":     "https://example.com/orders?a=1&b=2",
+			"db.statement": "SELECT * FROM \"orders\"\n\tWHERE id = 'é✓'",
+		},
+		Events:   []string{`{"name":"exception"}`},
+		HasError: true,
+	}
+}
+
+// The generated encoders must produce the same documents as encoding/json did,
+// as the query service parses the stored model and events.
+func TestEasyJSONMatchesEncodingJSON(t *testing.T) {
+	for _, v := range []interface {
+		easyjson.Marshaler
+	}{
+		testTraceModel(),
+		TraceModel{},
+		Event{Name: "exception", TimeUnixNano: 42, AttributeMap: map[string]string{"exception.message": "<nil> & more"}, IsError: true},
+		Event{},
+		Span{TraceModel: testTraceModel(), ErrorEvent: Event{Name: "exception"}, TagMap: map[string]string{"k": "v"}},
+	} {
+		expected, err := json.Marshal(v)
+		require.NoError(t, err)
+		actual, err := easyjson.Marshal(v)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(expected), string(actual))
+	}
+}
+
+func BenchmarkMarshalTraceModel(b *testing.B) {
+	model := testTraceModel()
+	b.Run("encoding/json", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = json.Marshal(model)
+		}
+	})
+	b.Run("easyjson", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = easyjson.Marshal(model)
+		}
+	})
+}
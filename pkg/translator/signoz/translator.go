@@ -0,0 +1,436 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package signoz converts OTLP spans to the span model SigNoz stores, so exporters,
+// encodings and tools writing that model share one conversion.
+package signoz // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/signoz"
+
+import (
+	"crypto/md5"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/google/uuid"
+	"github.com/mailru/easyjson"
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+)
+
+func makeJaegerProtoReferences(
+	links pdata.SpanLinkSlice,
+	parentSpanID pdata.SpanID,
+	traceID pdata.TraceID,
+) ([]OtelSpanRef, error) {
+
+	parentSpanIDSet := len(parentSpanID.Bytes()) != 0
+	if !parentSpanIDSet && links.Len() == 0 {
+		return nil, nil
+	}
+
+	refsCount := links.Len()
+	if parentSpanIDSet {
+		refsCount++
+	}
+
+	refs := make([]OtelSpanRef, 0, refsCount)
+
+	// Put parent span ID at the first place because usually backends look for it
+	// as the first CHILD_OF item in the model.SpanRef slice.
+	if parentSpanIDSet {
+
+		refs = append(refs, OtelSpanRef{
+			TraceId: traceID.HexString(),
+			SpanId:  parentSpanID.HexString(),
+			RefType: "CHILD_OF",
+		})
+	}
+
+	for i := 0; i < links.Len(); i++ {
+		link := links.At(i)
+
+		refs = append(refs, OtelSpanRef{
+			TraceId: link.TraceID().HexString(),
+			SpanId:  link.SpanID().HexString(),
+
+			// Since Jaeger RefType is not captured in internal data,
+			// use SpanRefType_FOLLOWS_FROM by default.
+			// SpanRefType_CHILD_OF supposed to be set only from parentSpanID.
+			RefType: "FOLLOWS_FROM",
+		})
+	}
+
+	return refs, nil
+}
+
+// ServiceNameForResource gets the service name for a specified Resource.
+func ServiceNameForResource(resource pdata.Resource) string {
+	// if resource.IsNil() {
+	// 	return "<nil-resource>"
+	// }
+
+	service, found := resource.Attributes().Get(conventions.AttributeServiceName)
+	if !found {
+		return "<nil-service-name>"
+	}
+
+	return service.StringVal()
+}
+
+// profileIDAttributes are the attributes profilers link spans to their profiles with.
+var profileIDAttributes = map[string]struct{}{
+	"pprof.profile.id":     {},
+	"pyroscope.profile.id": {},
+	"profile.id":           {},
+}
+
+func populateOtherDimensions(attributes pdata.AttributeMap, span *Span, errorMatcher *ErrorMatcher) {
+
+	attributes.Range(func(k string, v pdata.AttributeValue) bool {
+		if k == "http.status_code" || k == "http.response.status_code" {
+			code, ok := parseStatusCode(v)
+			if !ok {
+				return true
+			}
+			if errorMatcher.isHTTPError(code) {
+				span.HasError = true
+			}
+			span.HttpCode = strconv.FormatInt(code, 10)
+			span.ResponseStatusCode = span.HttpCode
+		} else if k == "http.url" && span.Kind == 3 {
+			value := v.StringVal()
+			valueUrl, err := url.Parse(value)
+			if err == nil {
+				value = valueUrl.Hostname()
+				span.HttpPath = valueUrl.Path
+			}
+			span.ExternalHttpUrl = value
+		} else if k == "http.method" && span.Kind == 3 {
+			span.ExternalHttpMethod = v.StringVal()
+		} else if k == "http.url" && span.Kind != 3 {
+			span.HttpUrl = v.StringVal()
+			if valueUrl, err := url.Parse(span.HttpUrl); err == nil {
+				span.HttpPath = valueUrl.Path
+			}
+		} else if k == "http.method" && span.Kind != 3 {
+			span.HttpMethod = v.StringVal()
+		} else if k == "http.route" {
+			span.HttpRoute = v.StringVal()
+		} else if k == "http.host" {
+			span.HttpHost = v.StringVal()
+		} else if k == "messaging.system" {
+			span.MsgSystem = v.StringVal()
+		} else if k == "messaging.operation" {
+			span.MsgOperation = v.StringVal()
+		} else if k == "component" {
+			span.Component = v.StringVal()
+		} else if k == "db.system" {
+			span.DBSystem = v.StringVal()
+		} else if k == "db.name" {
+			span.DBName = v.StringVal()
+		} else if k == "db.operation" {
+			span.DBOperation = v.StringVal()
+		} else if k == "peer.service" {
+			span.PeerService = v.StringVal()
+		} else if k == "rpc.grpc.status_code" {
+			code, ok := parseGRPCStatusCode(v)
+			if !ok {
+				return true
+			}
+			if code >= 2 {
+				span.HasError = true
+			}
+			span.GRPCCode = strconv.FormatInt(code, 10)
+			span.GRPCStatusName = grpcStatusName(code)
+			span.ResponseStatusCode = span.GRPCCode
+		} else if k == "rpc.method" {
+			span.RPCMethod = v.StringVal()
+			system, found := attributes.Get("rpc.system")
+			if found && system.StringVal() == "grpc" {
+				span.GRPCMethod = v.StringVal()
+			}
+		} else if k == "rpc.service" {
+			span.RPCService = v.StringVal()
+		} else if k == "rpc.system" {
+			span.RPCSystem = v.StringVal()
+		} else if k == "rpc.jsonrpc.error_code" {
+			span.ResponseStatusCode = v.StringVal()
+		} else if _, ok := profileIDAttributes[k]; ok {
+			span.ProfileID = v.AsString()
+		} else if k == "http.request_content_length" || k == "http.request.body.size" {
+			if size, ok := parseSize(v); ok {
+				span.HttpRequestSize = size
+			}
+		} else if k == "http.response_content_length" || k == "http.response.body.size" {
+			if size, ok := parseSize(v); ok {
+				span.HttpResponseSize = size
+			}
+		}
+		return true
+
+	})
+
+	// Server spans often only carry http.target, the path and query of the request.
+	if span.HttpPath == "" {
+		if target, found := attributes.Get("http.target"); found {
+			span.HttpPath = strings.SplitN(target.StringVal(), "?", 2)[0]
+		}
+	}
+}
+
+// populateResourceDimensions copies the resource attributes with columns of their own to the span.
+func populateResourceDimensions(attributes pdata.AttributeMap, span *Span) {
+	attributes.Range(func(k string, v pdata.AttributeValue) bool {
+		switch k {
+		case conventions.AttributeK8SNamespaceName:
+			span.K8sNamespaceName = v.StringVal()
+		case conventions.AttributeK8SPodName:
+			span.K8sPodName = v.StringVal()
+		case conventions.AttributeK8SDeploymentName:
+			span.K8sDeploymentName = v.StringVal()
+		case conventions.AttributeK8SNodeName:
+			span.K8sNodeName = v.StringVal()
+		case conventions.AttributeCloudProvider:
+			span.CloudProvider = v.StringVal()
+		case conventions.AttributeCloudRegion:
+			span.CloudRegion = v.StringVal()
+		case conventions.AttributeCloudAvailabilityZone:
+			span.CloudAvailabilityZone = v.StringVal()
+		case conventions.AttributeCloudAccountID:
+			span.CloudAccountID = v.StringVal()
+		case conventions.AttributeContainerID:
+			span.ContainerID = v.StringVal()
+		case conventions.AttributeContainerImageName:
+			span.ContainerImageName = v.StringVal()
+		case conventions.AttributeContainerImageTag:
+			span.ContainerImageTag = v.StringVal()
+		case conventions.AttributeHostName:
+			span.HostName = v.StringVal()
+		case conventions.AttributeOSType:
+			span.OSType = v.StringVal()
+		}
+		return true
+	})
+}
+
+func populateEvents(events pdata.SpanEventSlice, span *Span) {
+	for i := 0; i < events.Len(); i++ {
+		event := Event{}
+		event.Name = events.At(i).Name()
+		event.TimeUnixNano = uint64(events.At(i).Timestamp())
+		event.AttributeMap = map[string]string{}
+		event.IsError = false
+		events.At(i).Attributes().Range(func(k string, v pdata.AttributeValue) bool {
+			if v.Type().String() == "INT" {
+				event.AttributeMap[k] = strconv.FormatInt(v.IntVal(), 10)
+			} else {
+				event.AttributeMap[k] = v.AsString()
+			}
+			return true
+		})
+		if event.Name == "exception" {
+			event.IsError = true
+			span.ErrorEvent = event
+			uuidWithHyphen := uuid.New()
+			uuid := strings.Replace(uuidWithHyphen.String(), "-", "", -1)
+			span.ErrorID = uuid
+			hmd5 := md5.Sum([]byte(span.ServiceName + span.ErrorEvent.AttributeMap["exception.type"] + span.ErrorEvent.AttributeMap["exception.message"]))
+			span.ErrorGroupID = fmt.Sprintf("%x", hmd5)
+			// The last exception wins, don't keep the flag of an earlier one.
+			span.ErrorEscaped = false
+			if escaped, ok := events.At(i).Attributes().Get("exception.escaped"); ok {
+				span.ErrorEscaped = parseBool(escaped)
+			}
+		}
+		span.SpanEvents = append(span.SpanEvents, event)
+		stringEvent, _ := easyjson.Marshal(event)
+		span.Events = append(span.Events, string(stringEvent))
+	}
+}
+
+// parseStatusCode reads a status code, which SDKs send as an int, a double or a numeric string.
+func parseStatusCode(v pdata.AttributeValue) (int64, bool) {
+	switch v.Type() {
+	case pdata.AttributeValueTypeInt:
+		return v.IntVal(), true
+	case pdata.AttributeValueTypeDouble:
+		return int64(v.DoubleVal()), true
+	case pdata.AttributeValueTypeString:
+		code, err := strconv.ParseInt(strings.TrimSpace(v.StringVal()), 10, 64)
+		return code, err == nil
+	}
+	return 0, false
+}
+
+// parseSize reads a payload size in bytes, which some SDKs send as a string.
+func parseSize(v pdata.AttributeValue) (uint64, bool) {
+	switch v.Type() {
+	case pdata.AttributeValueTypeInt:
+		return uint64(v.IntVal()), v.IntVal() >= 0
+	case pdata.AttributeValueTypeString:
+		size, err := strconv.ParseUint(strings.TrimSpace(v.StringVal()), 10, 64)
+		return size, err == nil
+	}
+	return 0, false
+}
+
+// parseBool reads a boolean attribute. SDKs not emitting proper booleans send
+// true/false or 1/0 as strings or integers instead, anything else is false.
+func parseBool(v pdata.AttributeValue) bool {
+	switch v.Type() {
+	case pdata.AttributeValueTypeBool:
+		return v.BoolVal()
+	case pdata.AttributeValueTypeInt:
+		return v.IntVal() == 1
+	case pdata.AttributeValueTypeString:
+		switch strings.ToLower(strings.TrimSpace(v.StringVal())) {
+		case "true", "1":
+			return true
+		}
+	}
+	return false
+}
+
+// isComplexAttribute reports whether v is an array or a map, which tagMap keeps as compact JSON
+// when complex attributes are serialized.
+func isComplexAttribute(v pdata.AttributeValue) bool {
+	return v.Type() == pdata.AttributeValueTypeArray || v.Type() == pdata.AttributeValueTypeMap
+}
+
+// spanHash returns the xxHash64 of the hex trace ID followed by the hex span ID, which
+// ClickHouse computes as xxHash64(concat(traceID, spanID)).
+func spanHash(traceID string, spanID string) uint64 {
+	return xxhash.Sum64String(traceID + spanID)
+}
+
+func populateLinks(links pdata.SpanLinkSlice, span *Span) {
+	for i := 0; i < links.Len(); i++ {
+		link := Link{
+			TraceId:      links.At(i).TraceID().HexString(),
+			SpanId:       links.At(i).SpanID().HexString(),
+			TraceState:   string(links.At(i).TraceState()),
+			AttributeMap: map[string]string{},
+		}
+		links.At(i).Attributes().Range(func(k string, v pdata.AttributeValue) bool {
+			if v.Type().String() == "INT" {
+				link.AttributeMap[k] = strconv.FormatInt(v.IntVal(), 10)
+			} else {
+				link.AttributeMap[k] = v.AsString()
+			}
+			return true
+		})
+		span.SpanLinks = append(span.SpanLinks, link)
+	}
+}
+
+func populateTraceModel(span *Span) {
+	span.TraceModel.Events = span.Events
+	span.TraceModel.HasError = span.HasError
+}
+
+// Options controls how NewStructuredSpan converts a span.
+type Options struct {
+	// ErrorMatcher decides which spans besides the ones with an error status are flagged
+	// with hasError. A nil matcher applies the default HasErrorSettings.
+	ErrorMatcher *ErrorMatcher
+	// SerializeComplexAttributes keeps array and map attributes in the tag map as compact JSON.
+	SerializeComplexAttributes bool
+}
+
+// NewStructuredSpan converts otelSpan of the service to the SigNoz span model.
+func NewStructuredSpan(otelSpan pdata.Span, serviceName string, resource pdata.Resource, opts Options) *Span {
+	errorMatcher := opts.ErrorMatcher
+	if errorMatcher == nil {
+		// The default settings always parse.
+		errorMatcher, _ = NewErrorMatcher(HasErrorSettings{})
+	}
+	serializeComplexAttributes := opts.SerializeComplexAttributes
+
+	durationNano := uint64(otelSpan.EndTimestamp() - otelSpan.StartTimestamp())
+
+	attributes := otelSpan.Attributes()
+	resourceAttributes := resource.Attributes()
+	tagMap := map[string]string{}
+
+	attributes.Range(func(k string, v pdata.AttributeValue) bool {
+		v.StringVal()
+		if v.Type().String() == "INT" {
+			tagMap[k] = strconv.FormatInt(v.IntVal(), 10)
+		} else if serializeComplexAttributes && isComplexAttribute(v) {
+			tagMap[k] = v.AsString()
+		} else if v.StringVal() != "" {
+			tagMap[k] = v.StringVal()
+		}
+		return true
+
+	})
+
+	resourceAttributes.Range(func(k string, v pdata.AttributeValue) bool {
+		v.StringVal()
+		if v.Type().String() == "INT" {
+			tagMap[k] = strconv.FormatInt(v.IntVal(), 10)
+		} else if serializeComplexAttributes && isComplexAttribute(v) {
+			tagMap[k] = v.AsString()
+		} else if v.StringVal() != "" {
+			tagMap[k] = v.StringVal()
+		}
+		return true
+
+	})
+
+	references, _ := makeJaegerProtoReferences(otelSpan.Links(), otelSpan.ParentSpanID(), otelSpan.TraceID())
+
+	var span *Span = &Span{
+		TraceId:           otelSpan.TraceID().HexString(),
+		SpanId:            otelSpan.SpanID().HexString(),
+		ParentSpanId:      otelSpan.ParentSpanID().HexString(),
+		Name:              otelSpan.Name(),
+		StartTimeUnixNano: uint64(otelSpan.StartTimestamp()),
+		DurationNano:      durationNano,
+		ServiceName:       serviceName,
+		Kind:              int8(otelSpan.Kind()),
+		StatusCode:        int16(otelSpan.Status().Code()),
+		TagMap:            tagMap,
+		HasError:          false,
+		SpanHash:          spanHash(otelSpan.TraceID().HexString(), otelSpan.SpanID().HexString()),
+		TraceModel: TraceModel{
+			TraceId:           otelSpan.TraceID().HexString(),
+			SpanId:            otelSpan.SpanID().HexString(),
+			Name:              otelSpan.Name(),
+			DurationNano:      durationNano,
+			StartTimeUnixNano: uint64(otelSpan.StartTimestamp()),
+			ServiceName:       serviceName,
+			Kind:              int8(otelSpan.Kind()),
+			References:        references,
+			TagMap:            tagMap,
+			HasError:          false,
+		},
+	}
+
+	if span.StatusCode == 2 {
+		span.HasError = true
+	}
+	populateOtherDimensions(attributes, span, errorMatcher)
+	populateResourceDimensions(resourceAttributes, span)
+	if errorMatcher.matchesRules(attributes) {
+		span.HasError = true
+	}
+	populateEvents(otelSpan.Events(), span)
+	populateLinks(otelSpan.Links(), span)
+	populateTraceModel(span)
+
+	return span
+}
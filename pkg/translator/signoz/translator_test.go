@@ -0,0 +1,235 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signoz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestPopulateOtherDimensionsHTTPPath(t *testing.T) {
+	m, err := NewErrorMatcher(HasErrorSettings{})
+	require.NoError(t, err)
+
+	attributes := pdata.NewAttributeMap()
+	attributes.InsertString("http.url", "https://example.com/orders/42?expand=items")
+	attributes.InsertString("http.target", "/ignored")
+	span := &Span{}
+	populateOtherDimensions(attributes, span, m)
+	assert.Equal(t, "/orders/42", span.HttpPath)
+
+	attributes = pdata.NewAttributeMap()
+	attributes.InsertString("http.target", "/orders/42?expand=items")
+	span = &Span{}
+	populateOtherDimensions(attributes, span, m)
+	assert.Equal(t, "/orders/42", span.HttpPath)
+}
+
+func TestPopulateEventsExceptionEscaped(t *testing.T) {
+	tests := []struct {
+		name    string
+		escaped pdata.AttributeValue
+		want    bool
+	}{
+		{name: "bool", escaped: pdata.NewAttributeValueBool(true), want: true},
+		{name: "string", escaped: pdata.NewAttributeValueString("True"), want: true},
+		{name: "string numeric", escaped: pdata.NewAttributeValueString("1"), want: true},
+		{name: "int", escaped: pdata.NewAttributeValueInt(1), want: true},
+		{name: "false", escaped: pdata.NewAttributeValueString("0"), want: false},
+		{name: "invalid", escaped: pdata.NewAttributeValueString("yes"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := pdata.NewSpanEventSlice()
+			event := events.AppendEmpty()
+			event.SetName("exception")
+			event.Attributes().Insert("exception.escaped", tt.escaped)
+
+			span := &Span{}
+			populateEvents(events, span)
+			assert.Equal(t, tt.want, span.ErrorEscaped)
+			assert.Equal(t, tt.escaped.AsString(), span.ErrorEvent.AttributeMap["exception.escaped"])
+		})
+	}
+}
+
+func TestPopulateEventsLastExceptionEscaped(t *testing.T) {
+	events := pdata.NewSpanEventSlice()
+	first := events.AppendEmpty()
+	first.SetName("exception")
+	first.Attributes().InsertString("exception.type", "RetryableError")
+	first.Attributes().InsertBool("exception.escaped", true)
+	second := events.AppendEmpty()
+	second.SetName("exception")
+	second.Attributes().InsertString("exception.type", "ValueError")
+
+	span := &Span{}
+	populateEvents(events, span)
+	assert.Equal(t, "ValueError", span.ErrorEvent.AttributeMap["exception.type"])
+	assert.False(t, span.ErrorEscaped)
+	assert.Len(t, span.Events, 2)
+}
+
+func TestPopulateOtherDimensionsHTTPContentLength(t *testing.T) {
+	m, err := NewErrorMatcher(HasErrorSettings{})
+	require.NoError(t, err)
+
+	attributes := pdata.NewAttributeMap()
+	attributes.InsertInt("http.request_content_length", 512)
+	attributes.InsertString("http.response_content_length", "2048")
+	span := &Span{}
+	populateOtherDimensions(attributes, span, m)
+	assert.Equal(t, uint64(512), span.HttpRequestSize)
+	assert.Equal(t, uint64(2048), span.HttpResponseSize)
+
+	attributes = pdata.NewAttributeMap()
+	attributes.InsertInt("http.request.body.size", 64)
+	attributes.InsertString("http.response.body.size", "unknown")
+	span = &Span{}
+	populateOtherDimensions(attributes, span, m)
+	assert.Equal(t, uint64(64), span.HttpRequestSize)
+	assert.Equal(t, uint64(0), span.HttpResponseSize)
+}
+
+func TestNewStructuredSpanComplexAttributes(t *testing.T) {
+	m, err := NewErrorMatcher(HasErrorSettings{})
+	require.NoError(t, err)
+
+	span := pdata.NewSpan()
+	span.Attributes().InsertString("http.method", "GET")
+	headers := pdata.NewAttributeValueArray()
+	headers.SliceVal().AppendEmpty().SetStringVal("application/json")
+	headers.SliceVal().AppendEmpty().SetStringVal("text/plain")
+	span.Attributes().Insert("http.request.header.accept", headers)
+	// The resource attribute of the same key takes precedence.
+	shadowed := pdata.NewAttributeValueArray()
+	shadowed.SliceVal().AppendEmpty().SetStringVal("batch")
+	span.Attributes().Insert("tier", shadowed)
+
+	resource := pdata.NewResource()
+	resource.Attributes().InsertString("tier", "web")
+	labels := pdata.NewAttributeValueMap()
+	labels.MapVal().InsertString("app", "frontend")
+	resource.Attributes().Insert("k8s.pod.labels", labels)
+
+	structuredSpan := NewStructuredSpan(span, "frontend", resource, Options{ErrorMatcher: m, SerializeComplexAttributes: true})
+	assert.Equal(t, map[string]string{
+		"http.method":                "GET",
+		"http.request.header.accept": `["application/json","text/plain"]`,
+		"k8s.pod.labels":             `{"app":"frontend"}`,
+		"tier":                       "web",
+	}, structuredSpan.TagMap)
+	assert.Equal(t, structuredSpan.TagMap, structuredSpan.TraceModel.TagMap)
+
+	structuredSpan = NewStructuredSpan(span, "frontend", resource, Options{ErrorMatcher: m})
+	assert.Equal(t, map[string]string{
+		"http.method": "GET",
+		"tier":        "web",
+	}, structuredSpan.TagMap)
+}
+
+func TestNewStructuredSpanNilErrorMatcher(t *testing.T) {
+	span := pdata.NewSpan()
+	span.Attributes().InsertInt("http.status_code", 503)
+
+	structuredSpan := NewStructuredSpan(span, "frontend", pdata.NewResource(), Options{})
+	assert.True(t, structuredSpan.HasError)
+	assert.Equal(t, "frontend", structuredSpan.ServiceName)
+
+	span.Attributes().UpdateInt("http.status_code", 200)
+	structuredSpan = NewStructuredSpan(span, "frontend", pdata.NewResource(), Options{})
+	assert.False(t, structuredSpan.HasError)
+}
+
+func TestNewStructuredSpanLinks(t *testing.T) {
+	span := pdata.NewSpan()
+	link := span.Links().AppendEmpty()
+	link.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	link.SetSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	link.SetTraceState("vendor=value")
+	link.Attributes().InsertString("messaging.operation", "process")
+	link.Attributes().InsertInt("batch.index", 3)
+
+	structuredSpan := NewStructuredSpan(span, "frontend", pdata.NewResource(), Options{})
+	assert.Equal(t, []Link{{
+		TraceId:    "0102030405060708090a0b0c0d0e0f10",
+		SpanId:     "0102030405060708",
+		TraceState: "vendor=value",
+		AttributeMap: map[string]string{
+			"messaging.operation": "process",
+			"batch.index":         "3",
+		},
+	}}, structuredSpan.SpanLinks)
+}
+
+func TestNewStructuredSpanSpanHash(t *testing.T) {
+	span := pdata.NewSpan()
+	span.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	span.SetSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+
+	structuredSpan := NewStructuredSpan(span, "frontend", pdata.NewResource(), Options{})
+	// xxHash64(concat('0102030405060708090a0b0c0d0e0f10', '0102030405060708')) in ClickHouse.
+	assert.Equal(t, uint64(16540093359370569285), structuredSpan.SpanHash)
+}
+
+func TestPopulateOtherDimensionsProfileID(t *testing.T) {
+	m, err := NewErrorMatcher(HasErrorSettings{})
+	require.NoError(t, err)
+
+	for _, key := range []string{"pprof.profile.id", "pyroscope.profile.id", "profile.id"} {
+		attributes := pdata.NewAttributeMap()
+		attributes.InsertString(key, "5c1f7a3d")
+		span := &Span{}
+		populateOtherDimensions(attributes, span, m)
+		assert.Equal(t, "5c1f7a3d", span.ProfileID, key)
+	}
+}
+
+func TestPopulateResourceDimensions(t *testing.T) {
+	resourceAttributes := pdata.NewAttributeMap()
+	resourceAttributes.InsertString("k8s.namespace.name", "shop")
+	resourceAttributes.InsertString("k8s.pod.name", "frontend-7d9f8b6c5-x2x4q")
+	resourceAttributes.InsertString("k8s.deployment.name", "frontend")
+	resourceAttributes.InsertString("k8s.node.name", "node-1")
+	resourceAttributes.InsertString("cloud.provider", "aws")
+	resourceAttributes.InsertString("cloud.region", "eu-west-1")
+	resourceAttributes.InsertString("cloud.availability_zone", "eu-west-1a")
+	resourceAttributes.InsertString("cloud.account.id", "123456789012")
+	resourceAttributes.InsertString("container.id", "a3bf90e006b2")
+	resourceAttributes.InsertString("container.image.name", "shop/frontend")
+	resourceAttributes.InsertString("container.image.tag", "1.4.2")
+	resourceAttributes.InsertString("host.name", "ip-10-0-1-17")
+	resourceAttributes.InsertString("os.type", "linux")
+	span := &Span{}
+	populateResourceDimensions(resourceAttributes, span)
+	assert.Equal(t, &Span{
+		K8sNamespaceName:      "shop",
+		K8sPodName:            "frontend-7d9f8b6c5-x2x4q",
+		K8sDeploymentName:     "frontend",
+		K8sNodeName:           "node-1",
+		CloudProvider:         "aws",
+		CloudRegion:           "eu-west-1",
+		CloudAvailabilityZone: "eu-west-1a",
+		CloudAccountID:        "123456789012",
+		ContainerID:           "a3bf90e006b2",
+		ContainerImageName:    "shop/frontend",
+		ContainerImageTag:     "1.4.2",
+		HostName:              "ip-10-0-1-17",
+		OSType:                "linux",
+	}, span)
+}
@@ -48,6 +48,8 @@ func getAndSubPolicyEvaluator(logger *zap.Logger, cfg *AndSubPolicyCfg) (samplin
 		return sampling.NewRateLimiting(logger, rlfCfg.SpansPerSecond), nil
 	case StatusCode:
 		return sampling.NewStatusCodeFilter(logger, cfg.StatusCodeCfg.StatusCodes)
+	case SpanEvent:
+		return sampling.NewSpanEventFilter(logger, cfg.SpanEventCfg.EventNames)
 	case Probabilistic:
 		pfCfg := cfg.ProbabilisticCfg
 		return sampling.NewProbabilisticSampler(logger, pfCfg.HashSalt, pfCfg.SamplingPercentage), nil
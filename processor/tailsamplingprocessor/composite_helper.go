@@ -71,6 +71,8 @@ func getSubPolicyEvaluator(logger *zap.Logger, cfg *SubPolicyCfg) (sampling.Poli
 	case StatusCode:
 		scCfg := cfg.StatusCodeCfg
 		return sampling.NewStatusCodeFilter(logger, scCfg.StatusCodes)
+	case SpanEvent:
+		return sampling.NewSpanEventFilter(logger, cfg.SpanEventCfg.EventNames)
 	case StringAttribute:
 		safCfg := cfg.StringAttributeCfg
 		return sampling.NewStringAttributeFilter(logger, safCfg.Key, safCfg.Values, safCfg.EnabledRegexMatching, safCfg.CacheMaxSize, safCfg.InvertMatch), nil
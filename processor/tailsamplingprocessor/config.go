@@ -35,6 +35,9 @@ const (
 	Probabilistic PolicyType = "probabilistic"
 	// StatusCode sample traces that have a given status code.
 	StatusCode PolicyType = "status_code"
+	// SpanEvent sample traces that have a span with an event of a given name,
+	// e.g. exception.
+	SpanEvent PolicyType = "span_event"
 	// StringAttribute sample traces that a attribute, of type string, matching
 	// one of the listed values.
 	StringAttribute PolicyType = "string_attribute"
@@ -60,6 +63,8 @@ type SubPolicyCfg struct {
 	ProbabilisticCfg ProbabilisticCfg `mapstructure:"probabilistic"`
 	// Configs for status code filter sampling policy evaluator.
 	StatusCodeCfg StatusCodeCfg `mapstructure:"status_code"`
+	// Configs for span event filter sampling policy evaluator.
+	SpanEventCfg SpanEventCfg `mapstructure:"span_event"`
 	// Configs for string attribute filter sampling policy evaluator.
 	StringAttributeCfg StringAttributeCfg `mapstructure:"string_attribute"`
 	// Configs for rate limiting filter sampling policy evaluator.
@@ -85,6 +90,8 @@ type AndSubPolicyCfg struct {
 	LatencyCfg LatencyCfg `mapstructure:"latency"`
 	// Configs for status code filter sampling policy evaluator.
 	StatusCodeCfg StatusCodeCfg `mapstructure:"status_code"`
+	// Configs for span event filter sampling policy evaluator.
+	SpanEventCfg SpanEventCfg `mapstructure:"span_event"`
 }
 
 type AndCfg struct {
@@ -120,6 +127,8 @@ type PolicyCfg struct {
 	ProbabilisticCfg ProbabilisticCfg `mapstructure:"probabilistic"`
 	// Configs for status code filter sampling policy evaluator.
 	StatusCodeCfg StatusCodeCfg `mapstructure:"status_code"`
+	// Configs for span event filter sampling policy evaluator.
+	SpanEventCfg SpanEventCfg `mapstructure:"span_event"`
 	// Configs for string attribute filter sampling policy evaluator.
 	StringAttributeCfg StringAttributeCfg `mapstructure:"string_attribute"`
 	// Configs for rate limiting filter sampling policy evaluator.
@@ -166,6 +175,13 @@ type StatusCodeCfg struct {
 	StatusCodes []string `mapstructure:"status_codes"`
 }
 
+// SpanEventCfg holds the configurable settings to create a span event filter sampling
+// policy evaluator.
+type SpanEventCfg struct {
+	// EventNames are the span event names a trace is sampled for.
+	EventNames []string `mapstructure:"event_names"`
+}
+
 // StringAttributeCfg holds the configurable settings to create a string attribute filter
 // sampling policy evaluator.
 type StringAttributeCfg struct {
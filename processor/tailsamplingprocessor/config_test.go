@@ -78,6 +78,11 @@ func TestLoadConfig(t *testing.T) {
 					Type:            RateLimiting,
 					RateLimitingCfg: RateLimitingCfg{SpansPerSecond: 35},
 				},
+				{
+					Name:         "test-policy-10",
+					Type:         SpanEvent,
+					SpanEventCfg: SpanEventCfg{EventNames: []string{"exception"}},
+				},
 				{
 					Name: "and-policy-1",
 					Type: And,
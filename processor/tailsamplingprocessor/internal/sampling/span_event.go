@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+type spanEventFilter struct {
+	logger     *zap.Logger
+	eventNames map[string]struct{}
+}
+
+var _ PolicyEvaluator = (*spanEventFilter)(nil)
+
+// NewSpanEventFilter creates a policy evaluator that samples all traces with
+// a span carrying an event with one of the given names, e.g. exception.
+func NewSpanEventFilter(logger *zap.Logger, eventNames []string) (PolicyEvaluator, error) {
+	if len(eventNames) == 0 {
+		return nil, errors.New("expected at least one event name to filter on")
+	}
+
+	names := make(map[string]struct{}, len(eventNames))
+	for _, name := range eventNames {
+		names[name] = struct{}{}
+	}
+
+	return &spanEventFilter{
+		logger:     logger,
+		eventNames: names,
+	}, nil
+}
+
+// OnLateArrivingSpans notifies the evaluator that the given list of spans arrived
+// after the sampling decision was already taken for the trace.
+// This gives the evaluator a chance to log any message/metrics and/or update any
+// related internal state.
+func (f *spanEventFilter) OnLateArrivingSpans(Decision, []*pdata.Span) error {
+	f.logger.Debug("Triggering action for late arriving spans in span event filter")
+	return nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (f *spanEventFilter) Evaluate(_ pdata.TraceID, trace *TraceData) (Decision, error) {
+	f.logger.Debug("Evaluating spans in span event filter")
+
+	trace.Lock()
+	batches := trace.ReceivedBatches
+	trace.Unlock()
+
+	return hasSpanWithCondition(batches, func(span pdata.Span) bool {
+		events := span.Events()
+		for i := 0; i < events.Len(); i++ {
+			if _, ok := f.eventNames[events.At(i).Name()]; ok {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+func TestNewSpanEventFilter_errorHandling(t *testing.T) {
+	_, err := NewSpanEventFilter(zap.NewNop(), []string{})
+	assert.EqualError(t, err, "expected at least one event name to filter on")
+}
+
+func TestSpanEventSampling(t *testing.T) {
+	traceID := pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+
+	cases := []struct {
+		Desc          string
+		EventNames    []string
+		EventsPresent [][]string
+		Decision      Decision
+	}{
+		{
+			Desc:          "no events",
+			EventNames:    []string{"exception"},
+			EventsPresent: [][]string{{}, {}},
+			Decision:      NotSampled,
+		},
+		{
+			Desc:          "other events",
+			EventNames:    []string{"exception"},
+			EventsPresent: [][]string{{"message"}, {"retry", "message"}},
+			Decision:      NotSampled,
+		},
+		{
+			Desc:          "event on one span",
+			EventNames:    []string{"exception"},
+			EventsPresent: [][]string{{"message"}, {"message", "exception"}},
+			Decision:      Sampled,
+		},
+		{
+			Desc:          "one of several names",
+			EventNames:    []string{"exception", "panic"},
+			EventsPresent: [][]string{{"panic"}},
+			Decision:      Sampled,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			traces := pdata.NewTraces()
+			ils := traces.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty()
+			for _, names := range c.EventsPresent {
+				span := ils.Spans().AppendEmpty()
+				span.SetTraceID(traceID)
+				for _, name := range names {
+					span.Events().AppendEmpty().SetName(name)
+				}
+			}
+			trace := &TraceData{ReceivedBatches: []pdata.Traces{traces}}
+
+			filter, err := NewSpanEventFilter(zap.NewNop(), c.EventNames)
+			require.NoError(t, err)
+
+			decision, err := filter.Evaluate(traceID, trace)
+			assert.NoError(t, err)
+			assert.Equal(t, c.Decision, decision)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinoteventsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pinoteventsreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pinot"
+)
+
+var errInvalidPollInterval = errors.New(`"poll_interval" must be positive`)
+
+// Config defines the configuration for the Pinot events receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"`
+	// HTTPClientSettings points at the Pinot controller.
+	confighttp.HTTPClientSettings `mapstructure:",squash"`
+
+	// PollInterval is the time between two polls of the controller. Events
+	// are found by comparing the cluster state of consecutive polls.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+
+	// Tables limits polling to the given tables. All tables known to the
+	// controller are polled when empty.
+	Tables []string `mapstructure:"tables"`
+
+	// Retry defines how failed requests against the controller are retried.
+	Retry pinot.RetrySettings `mapstructure:"retry_on_failure"`
+}
+
+// Validate validates the endpoint and poll interval of the receiver configuration.
+func (cfg *Config) Validate() error {
+	var err error
+	if _, parseErr := url.Parse(cfg.Endpoint); parseErr != nil {
+		err = multierr.Append(err, fmt.Errorf("\"endpoint\" %q must be in the form of <scheme>://<hostname>:<port>: %w", cfg.Endpoint, parseErr))
+	}
+	if cfg.PollInterval <= 0 {
+		err = multierr.Append(err, errInvalidPollInterval)
+	}
+	return err
+}
@@ -0,0 +1,280 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinoteventsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pinoteventsreceiver"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pinot"
+)
+
+const (
+	eventSegmentCreated   = "segment_created"
+	eventSegmentDeleted   = "segment_deleted"
+	eventRebalance        = "rebalance"
+	eventConsumptionError = "consumption_error"
+
+	// consumerStateConsuming is the state of a consumer that is not in error.
+	consumerStateConsuming = "CONSUMING"
+	// rebalanceStatusFailed is the status of a rebalance job that gave up.
+	rebalanceStatusFailed = "FAILED"
+)
+
+var severityText = map[pdata.SeverityNumber]string{
+	pdata.SeverityNumberINFO:  "INFO",
+	pdata.SeverityNumberERROR: "ERROR",
+}
+
+// tableState is what a poll saw of a table. Events are the differences
+// between the states of two consecutive polls.
+type tableState struct {
+	// segments maps segment names to their table type.
+	segments map[string]string
+	// rebalanceJobs maps rebalance job ids to their table type and status.
+	rebalanceJobs map[string]rebalanceJob
+	// consumers maps segment and server pairs to the state of their consumer.
+	consumers map[consumerKey]string
+}
+
+type rebalanceJob struct {
+	tableType string
+	status    string
+}
+
+type consumerKey struct {
+	segment string
+	server  string
+}
+
+// poll fetches the state of every table and returns the events found by
+// comparing it with the previous poll. Tables seen for the first time only
+// establish a baseline, so that starting the receiver does not report every
+// existing segment as created.
+func (r *eventsReceiver) poll(ctx context.Context) (pdata.Logs, error) {
+	ld := pdata.NewLogs()
+	ill := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty()
+	ill.InstrumentationLibrary().SetName("otelcol/pinotevents")
+
+	tables := r.config.Tables
+	if len(tables) == 0 {
+		var err error
+		if tables, err = r.client.ListTables(ctx); err != nil {
+			return ld, fmt.Errorf("failed to list tables: %w", err)
+		}
+	}
+
+	now := pdata.NewTimestampFromTime(time.Now())
+	current := make(map[string]*tableState, len(tables))
+	var errs error
+	for _, table := range tables {
+		state, err := r.fetchTableState(ctx, table)
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed to poll table %s: %w", table, err))
+			// Keep the previous state so that changes are reported once the
+			// table can be polled again.
+			if prev, ok := r.tables[table]; ok {
+				current[table] = prev
+			}
+			continue
+		}
+		if prev, ok := r.tables[table]; ok {
+			appendEvents(ill.LogRecords(), now, table, prev, state)
+		}
+		current[table] = state
+	}
+	r.tables = current
+	return ld, errs
+}
+
+func (r *eventsReceiver) fetchTableState(ctx context.Context, table string) (*tableState, error) {
+	state := &tableState{
+		segments:      make(map[string]string),
+		rebalanceJobs: make(map[string]rebalanceJob),
+		consumers:     make(map[consumerKey]string),
+	}
+
+	// The controller answers with one object per table type, e.g. [{"OFFLINE":[...]},{"REALTIME":[...]}].
+	var segments []map[string][]string
+	if err := r.client.Get(ctx, "/segments/"+url.PathEscape(table), &segments); err != nil {
+		return nil, err
+	}
+	realtime := false
+	for _, byType := range segments {
+		for tableType, names := range byType {
+			tableType = strings.ToLower(tableType)
+			realtime = realtime || tableType == "realtime"
+			for _, name := range names {
+				state.segments[name] = tableType
+			}
+		}
+	}
+
+	// Only realtime tables have consuming segments; the controller rejects the
+	// request for offline-only tables.
+	if realtime {
+		var info struct {
+			SegmentToConsumingInfo map[string][]struct {
+				ServerName    string `json:"serverName"`
+				ConsumerState string `json:"consumerState"`
+			} `json:"_segmentToConsumingInfoMap"`
+		}
+		if err := r.client.Get(ctx, fmt.Sprintf("/tables/%s/consumingSegmentsInfo", url.PathEscape(table)), &info); err != nil {
+			return nil, err
+		}
+		for segment, replicas := range info.SegmentToConsumingInfo {
+			for _, replica := range replicas {
+				state.consumers[consumerKey{segment: segment, server: replica.ServerName}] = replica.ConsumerState
+			}
+		}
+	}
+
+	// Every value of a job is a string; the progress is itself JSON encoded.
+	var jobs map[string]map[string]string
+	err := r.client.Get(ctx, fmt.Sprintf("/table/%s/jobs?jobTypes=TABLE_REBALANCE", url.PathEscape(table)), &jobs)
+	switch {
+	case isNotFound(err):
+		// Controllers before 0.12 do not keep track of rebalance jobs.
+	case err != nil:
+		return nil, err
+	}
+	for id, job := range jobs {
+		var progress struct {
+			Status string `json:"status"`
+		}
+		// A job without parsable progress is still reported, without status.
+		_ = json.Unmarshal([]byte(job["REBALANCE_PROGRESS_STATS"]), &progress)
+		state.rebalanceJobs[id] = rebalanceJob{
+			tableType: tableTypeOf(job["tableName"]),
+			status:    progress.Status,
+		}
+	}
+	return state, nil
+}
+
+// appendEvents appends a log record for every difference between the previous
+// and current state of a table.
+func appendEvents(logs pdata.LogRecordSlice, now pdata.Timestamp, table string, prev, cur *tableState) {
+	for _, name := range sortedKeys(cur.segments) {
+		if _, ok := prev.segments[name]; !ok {
+			lr := appendEvent(logs, now, eventSegmentCreated, table, pdata.SeverityNumberINFO,
+				fmt.Sprintf("Segment %s of table %s was created", name, table))
+			lr.Attributes().InsertString("pinot.table.type", cur.segments[name])
+			lr.Attributes().InsertString("pinot.segment.name", name)
+		}
+	}
+	for _, name := range sortedKeys(prev.segments) {
+		if _, ok := cur.segments[name]; !ok {
+			lr := appendEvent(logs, now, eventSegmentDeleted, table, pdata.SeverityNumberINFO,
+				fmt.Sprintf("Segment %s of table %s was deleted", name, table))
+			lr.Attributes().InsertString("pinot.table.type", prev.segments[name])
+			lr.Attributes().InsertString("pinot.segment.name", name)
+		}
+	}
+
+	ids := make([]string, 0, len(cur.rebalanceJobs))
+	for id := range cur.rebalanceJobs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		job := cur.rebalanceJobs[id]
+		if prevJob, ok := prev.rebalanceJobs[id]; ok && prevJob.status == job.status {
+			continue
+		}
+		severity := pdata.SeverityNumberINFO
+		if job.status == rebalanceStatusFailed {
+			severity = pdata.SeverityNumberERROR
+		}
+		lr := appendEvent(logs, now, eventRebalance, table, severity,
+			fmt.Sprintf("Rebalance %s of table %s is %s", id, table, job.status))
+		if job.tableType != "" {
+			lr.Attributes().InsertString("pinot.table.type", job.tableType)
+		}
+		lr.Attributes().InsertString("pinot.rebalance.job_id", id)
+		lr.Attributes().InsertString("pinot.rebalance.status", job.status)
+	}
+
+	keys := make([]consumerKey, 0, len(cur.consumers))
+	for key := range cur.consumers {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].segment != keys[j].segment {
+			return keys[i].segment < keys[j].segment
+		}
+		return keys[i].server < keys[j].server
+	})
+	for _, key := range keys {
+		// A consumer is reported once when it stops consuming, not on every poll.
+		consumerState := cur.consumers[key]
+		if consumerState == consumerStateConsuming || prev.consumers[key] == consumerState {
+			continue
+		}
+		lr := appendEvent(logs, now, eventConsumptionError, table, pdata.SeverityNumberERROR,
+			fmt.Sprintf("Consumer of segment %s on server %s is %s", key.segment, key.server, consumerState))
+		lr.Attributes().InsertString("pinot.table.type", "realtime")
+		lr.Attributes().InsertString("pinot.segment.name", key.segment)
+		lr.Attributes().InsertString("pinot.server.name", key.server)
+		lr.Attributes().InsertString("pinot.consumer.state", consumerState)
+	}
+}
+
+func appendEvent(logs pdata.LogRecordSlice, now pdata.Timestamp, event, table string, severity pdata.SeverityNumber, message string) pdata.LogRecord {
+	lr := logs.AppendEmpty()
+	lr.SetTimestamp(now)
+	lr.SetSeverityNumber(severity)
+	lr.SetSeverityText(severityText[severity])
+	lr.Body().SetStringVal(message)
+	lr.Attributes().InsertString("pinot.event.type", event)
+	lr.Attributes().InsertString("pinot.table.name", table)
+	return lr
+}
+
+// tableTypeOf returns the lower case type of a table name carrying a type
+// suffix, e.g. realtime for signoz_metrics_REALTIME.
+func tableTypeOf(name string) string {
+	switch {
+	case strings.HasSuffix(name, "_REALTIME"):
+		return "realtime"
+	case strings.HasSuffix(name, "_OFFLINE"):
+		return "offline"
+	}
+	return ""
+}
+
+func isNotFound(err error) bool {
+	var statusErr *pinot.StatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinoteventsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pinoteventsreceiver"
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// mockController serves controller responses that tests can change between polls.
+type mockController struct {
+	mu       sync.Mutex
+	routes   map[string]string
+	requests map[string]int
+}
+
+func (m *mockController) set(path, body string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.routes[path] = body
+}
+
+func (m *mockController) count(path string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.requests[path]
+}
+
+func (m *mockController) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[r.URL.Path]++
+	body, ok := m.routes[r.URL.Path]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_, _ = w.Write([]byte(body))
+}
+
+func newMockController(t *testing.T) (*mockController, *httptest.Server) {
+	controller := &mockController{requests: make(map[string]int), routes: map[string]string{
+		"/tables":                  `{"tables":["signoz_metrics"]}`,
+		"/segments/signoz_metrics": `[{"REALTIME":["seg__0__0","seg__1__0"]}]`,
+		"/tables/signoz_metrics/consumingSegmentsInfo": `{"_segmentToConsumingInfoMap":{"seg__1__0":[{"serverName":"Server_1","consumerState":"CONSUMING"}]}}`,
+	}}
+	server := httptest.NewServer(controller)
+	t.Cleanup(server.Close)
+	return controller, server
+}
+
+func newTestReceiver(t *testing.T, endpoint string) *eventsReceiver {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint
+	cfg.Retry.Enabled = false
+	r := newEventsReceiver(componenttest.NewNopReceiverCreateSettings(), cfg, consumertest.NewNop())
+	// Shutdown stops the poll loop started by Start, the tests poll themselves.
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, r.Shutdown(context.Background()))
+	r.tables = make(map[string]*tableState)
+	return r
+}
+
+func eventAttributes(t *testing.T, ld pdata.Logs) []map[string]interface{} {
+	var events []map[string]interface{}
+	logs := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).LogRecords()
+	for i := 0; i < logs.Len(); i++ {
+		attrs := logs.At(i).Attributes().AsRaw()
+		attrs["severity"] = logs.At(i).SeverityText()
+		events = append(events, attrs)
+	}
+	return events
+}
+
+func TestPoll(t *testing.T) {
+	controller, server := newMockController(t)
+	r := newTestReceiver(t, server.URL)
+
+	// The first poll only establishes the baseline.
+	ld, err := r.poll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, ld.LogRecordCount())
+
+	controller.set("/segments/signoz_metrics", `[{"REALTIME":["seg__1__0","seg__1__1"]}]`)
+	controller.set("/tables/signoz_metrics/consumingSegmentsInfo", `{"_segmentToConsumingInfoMap":{"seg__1__1":[{"serverName":"Server_1","consumerState":"NOT_CONSUMING"}]}}`)
+	controller.set("/table/signoz_metrics/jobs", `{"job-1":{"jobId":"job-1","tableName":"signoz_metrics_REALTIME","jobType":"TABLE_REBALANCE","REBALANCE_PROGRESS_STATS":"{\"status\":\"FAILED\"}"}}`)
+
+	ld, err = r.poll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{
+		{"pinot.event.type": "segment_created", "pinot.table.name": "signoz_metrics", "pinot.table.type": "realtime", "pinot.segment.name": "seg__1__1", "severity": "INFO"},
+		{"pinot.event.type": "segment_deleted", "pinot.table.name": "signoz_metrics", "pinot.table.type": "realtime", "pinot.segment.name": "seg__0__0", "severity": "INFO"},
+		{"pinot.event.type": "rebalance", "pinot.table.name": "signoz_metrics", "pinot.table.type": "realtime", "pinot.rebalance.job_id": "job-1", "pinot.rebalance.status": "FAILED", "severity": "ERROR"},
+		{"pinot.event.type": "consumption_error", "pinot.table.name": "signoz_metrics", "pinot.table.type": "realtime", "pinot.segment.name": "seg__1__1", "pinot.server.name": "Server_1", "pinot.consumer.state": "NOT_CONSUMING", "severity": "ERROR"},
+	}, eventAttributes(t, ld))
+	body := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).LogRecords().At(0).Body().StringVal()
+	assert.Equal(t, "Segment seg__1__1 of table signoz_metrics was created", body)
+
+	// Nothing changed, so nothing is reported again.
+	ld, err = r.poll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, ld.LogRecordCount())
+}
+
+func TestPollErrors(t *testing.T) {
+	controller, server := newMockController(t)
+	r := newTestReceiver(t, server.URL)
+
+	_, err := r.poll(context.Background())
+	require.NoError(t, err)
+
+	// Changes made while a table cannot be polled are reported afterwards.
+	controller.set("/segments/signoz_metrics", `[{"REALTIME":["seg__0__0","seg__1__0","seg__1__1"]}]`)
+	controller.set("/tables/signoz_metrics/consumingSegmentsInfo", "invalid")
+	ld, err := r.poll(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to poll table signoz_metrics")
+	assert.Equal(t, 0, ld.LogRecordCount())
+
+	controller.set("/tables/signoz_metrics/consumingSegmentsInfo", `{}`)
+	ld, err = r.poll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, ld.LogRecordCount())
+
+	controller.set("/tables", "invalid")
+	r.config.Tables = nil
+	_, err = r.poll(context.Background())
+	assert.Error(t, err)
+}
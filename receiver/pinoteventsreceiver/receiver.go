@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinoteventsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pinoteventsreceiver"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pinot"
+)
+
+type eventsReceiver struct {
+	config   *Config
+	settings component.ReceiverCreateSettings
+	consumer consumer.Logs
+	obsrecv  *obsreport.Receiver
+
+	client *pinot.Client
+	// tables holds the state of every table seen by the previous poll.
+	tables map[string]*tableState
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newEventsReceiver(settings component.ReceiverCreateSettings, config *Config, consumer consumer.Logs) *eventsReceiver {
+	return &eventsReceiver{
+		config:   config,
+		settings: settings,
+		consumer: consumer,
+		obsrecv: obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             config.ID(),
+			Transport:              "http",
+			ReceiverCreateSettings: settings,
+		}),
+		tables: make(map[string]*tableState),
+	}
+}
+
+func (r *eventsReceiver) Start(_ context.Context, host component.Host) error {
+	httpClient, err := r.config.ToClient(host.GetExtensions(), r.settings.TelemetrySettings)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP Client: %w", err)
+	}
+	r.client = pinot.NewClient(r.config.Endpoint, httpClient, r.config.Retry, r.settings.Logger)
+
+	var ctx context.Context
+	ctx, r.cancel = context.WithCancel(context.Background())
+	r.wg.Add(1)
+	go r.run(ctx)
+	return nil
+}
+
+func (r *eventsReceiver) Shutdown(context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	return nil
+}
+
+func (r *eventsReceiver) run(ctx context.Context) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		r.pollAndConsume(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *eventsReceiver) pollAndConsume(ctx context.Context) {
+	ld, err := r.poll(ctx)
+	if err != nil {
+		r.settings.Logger.Error("Failed to poll pinot controller",
+			zap.String("endpoint", r.config.Endpoint),
+			zap.Error(err),
+		)
+	}
+	if ld.LogRecordCount() == 0 {
+		return
+	}
+
+	obsCtx := r.obsrecv.StartLogsOp(ctx)
+	consumerErr := r.consumer.ConsumeLogs(obsCtx, ld)
+	r.obsrecv.EndLogsOp(obsCtx, typeStr, ld.LogRecordCount(), consumerErr)
+}
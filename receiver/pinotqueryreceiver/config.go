@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinotqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pinotqueryreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pinot"
+)
+
+const (
+	// MetricDataTypeGauge records the values of a column as a gauge.
+	MetricDataTypeGauge = "gauge"
+	// MetricDataTypeSum records the values of a column as a cumulative sum.
+	MetricDataTypeSum = "sum"
+
+	// MetricValueTypeInt records values as integers.
+	MetricValueTypeInt = "int"
+	// MetricValueTypeDouble records values as doubles.
+	MetricValueTypeDouble = "double"
+)
+
+// Config defines the configuration for the Pinot query receiver.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+	// HTTPClientSettings points at the Pinot broker.
+	confighttp.HTTPClientSettings `mapstructure:",squash"`
+
+	// Retry defines how failed queries are retried.
+	Retry pinot.RetrySettings `mapstructure:"retry_on_failure"`
+
+	// Queries are run on every collection, each producing the configured metrics.
+	Queries []Query `mapstructure:"queries"`
+}
+
+// Query is a SQL query and the metrics built from its result rows.
+type Query struct {
+	// SQL is the query sent to the broker.
+	SQL string `mapstructure:"sql"`
+
+	// Metrics each get one data point per result row.
+	Metrics []MetricConfig `mapstructure:"metrics"`
+}
+
+// MetricConfig maps the columns of a query result to a metric.
+type MetricConfig struct {
+	// MetricName is the name of the metric.
+	MetricName string `mapstructure:"metric_name"`
+
+	// ValueColumn is the column holding the value of the data points.
+	ValueColumn string `mapstructure:"value_column"`
+
+	// AttributeColumns are the columns copied to the attributes of the data points.
+	AttributeColumns []string `mapstructure:"attribute_columns"`
+
+	// Description of the metric.
+	Description string `mapstructure:"description"`
+
+	// Unit of the metric.
+	Unit string `mapstructure:"unit"`
+
+	// DataType is gauge or sum (default gauge).
+	DataType string `mapstructure:"data_type"`
+
+	// ValueType is int or double (default double).
+	ValueType string `mapstructure:"value_type"`
+
+	// Monotonic marks a sum as monotonic.
+	Monotonic bool `mapstructure:"monotonic"`
+}
+
+// Validate validates the endpoint and queries of the receiver configuration.
+func (cfg *Config) Validate() error {
+	var err error
+	if _, parseErr := url.Parse(cfg.Endpoint); parseErr != nil {
+		err = multierr.Append(err, fmt.Errorf("\"endpoint\" %q must be in the form of <scheme>://<hostname>:<port>: %w", cfg.Endpoint, parseErr))
+	}
+	if len(cfg.Queries) == 0 {
+		err = multierr.Append(err, errors.New("queries must not be empty"))
+	}
+	for i, query := range cfg.Queries {
+		err = multierr.Append(err, query.validate(i))
+	}
+	return err
+}
+
+func (q Query) validate(i int) error {
+	var err error
+	if q.SQL == "" {
+		err = multierr.Append(err, fmt.Errorf("queries[%d].sql must not be empty", i))
+	}
+	if len(q.Metrics) == 0 {
+		err = multierr.Append(err, fmt.Errorf("queries[%d].metrics must not be empty", i))
+	}
+	for j, metric := range q.Metrics {
+		if metric.MetricName == "" {
+			err = multierr.Append(err, fmt.Errorf("queries[%d].metrics[%d].metric_name must not be empty", i, j))
+		}
+		if metric.ValueColumn == "" {
+			err = multierr.Append(err, fmt.Errorf("queries[%d].metrics[%d].value_column must not be empty", i, j))
+		}
+		switch metric.DataType {
+		case "", MetricDataTypeGauge, MetricDataTypeSum:
+		default:
+			err = multierr.Append(err, fmt.Errorf("queries[%d].metrics[%d].data_type must be gauge or sum. configured value %q", i, j, metric.DataType))
+		}
+		switch metric.ValueType {
+		case "", MetricValueTypeInt, MetricValueTypeDouble:
+		default:
+			err = multierr.Append(err, fmt.Errorf("queries[%d].metrics[%d].value_type must be int or double. configured value %q", i, j, metric.ValueType))
+		}
+	}
+	return err
+}
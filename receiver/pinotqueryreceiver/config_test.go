@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinotqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pinotqueryreceiver"
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/service/servicetest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.Len(t, cfg.Receivers, 1)
+
+	r := cfg.Receivers[config.NewComponentID(typeStr)].(*Config)
+	require.Equal(t, "http://localhost:8099", r.Endpoint)
+	require.Equal(t, 30*time.Second, r.CollectionInterval)
+	require.Equal(t, []Query{{
+		SQL: "SELECT serviceName, COUNT(*) AS errors FROM signoz_error_index WHERE timestampMs > ago('PT1M') GROUP BY serviceName",
+		Metrics: []MetricConfig{{
+			MetricName:       "signoz.errors",
+			ValueColumn:      "errors",
+			AttributeColumns: []string{"serviceName"},
+			ValueType:        MetricValueTypeInt,
+			Unit:             "{errors}",
+		}},
+	}}, r.Queries)
+}
+
+func TestValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	require.EqualError(t, cfg.Validate(), "queries must not be empty")
+
+	cfg.Queries = []Query{
+		{SQL: "SELECT COUNT(*) AS c FROM t", Metrics: []MetricConfig{{MetricName: "c", ValueColumn: "c"}}},
+		{Metrics: []MetricConfig{{MetricName: "c", DataType: "histogram"}}},
+	}
+	require.EqualError(t, cfg.Validate(), "queries[1].sql must not be empty; "+
+		"queries[1].metrics[0].value_column must not be empty; "+
+		"queries[1].metrics[0].data_type must be gauge or sum. configured value \"histogram\"")
+
+	cfg.Queries = cfg.Queries[:1]
+	require.NoError(t, cfg.Validate())
+
+	cfg.Endpoint = "http://localhost :8099"
+	require.EqualError(t, cfg.Validate(), `"endpoint" "http://localhost :8099" must be in the form of <scheme>://<hostname>:<port>: `+
+		`parse "http://localhost :8099": invalid character " " in host name`)
+}
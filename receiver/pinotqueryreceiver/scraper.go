@@ -0,0 +1,218 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinotqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pinotqueryreceiver"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pinot"
+)
+
+// brokerResponse is the subset of the broker's /query/sql response used by the scraper.
+type brokerResponse struct {
+	ResultTable *resultTable `json:"resultTable"`
+	Exceptions  []struct {
+		ErrorCode int    `json:"errorCode"`
+		Message   string `json:"message"`
+	} `json:"exceptions"`
+}
+
+type resultTable struct {
+	DataSchema struct {
+		ColumnNames []string `json:"columnNames"`
+	} `json:"dataSchema"`
+	Rows [][]json.RawMessage `json:"rows"`
+}
+
+type queryScraper struct {
+	client    *pinot.Client
+	config    *Config
+	settings  component.TelemetrySettings
+	startTime pdata.Timestamp
+}
+
+func newQueryScraper(settings component.TelemetrySettings, config *Config) *queryScraper {
+	return &queryScraper{
+		settings: settings,
+		config:   config,
+	}
+}
+
+func (s *queryScraper) start(_ context.Context, host component.Host) error {
+	httpClient, err := s.config.ToClient(host.GetExtensions(), s.settings)
+	if err != nil {
+		return fmt.Errorf("failed to start: %w", err)
+	}
+	s.client = pinot.NewClient(s.config.Endpoint, httpClient, s.config.Retry, s.settings.Logger)
+	s.startTime = pdata.NewTimestampFromTime(time.Now())
+	return nil
+}
+
+func (s *queryScraper) scrape(ctx context.Context) (pdata.Metrics, error) {
+	if s.client == nil {
+		return pdata.NewMetrics(), errors.New("no client available")
+	}
+
+	md := pdata.NewMetrics()
+	ilm := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty()
+	ilm.InstrumentationLibrary().SetName("otelcol/pinotquery")
+
+	now := pdata.NewTimestampFromTime(time.Now())
+	var errs scrapererror.ScrapeErrors
+	for _, query := range s.config.Queries {
+		table, err := s.query(ctx, query.SQL)
+		if err != nil {
+			errs.AddPartial(len(query.Metrics), fmt.Errorf("failed to run query %q: %w", query.SQL, err))
+			continue
+		}
+		for _, metric := range query.Metrics {
+			if err := s.recordMetric(ilm.Metrics(), now, metric, table); err != nil {
+				errs.AddPartial(1, err)
+			}
+		}
+	}
+	return md, errs.Combine()
+}
+
+func (s *queryScraper) query(ctx context.Context, sql string) (*resultTable, error) {
+	var resp brokerResponse
+	if err := s.client.Post(ctx, "/query/sql", map[string]string{"sql": sql}, &resp); err != nil {
+		return nil, err
+	}
+	// The broker answers failed queries with 200 and the reasons in exceptions.
+	if len(resp.Exceptions) > 0 {
+		messages := make([]string, len(resp.Exceptions))
+		for i, exception := range resp.Exceptions {
+			messages[i] = fmt.Sprintf("%d: %s", exception.ErrorCode, exception.Message)
+		}
+		return nil, fmt.Errorf("broker returned exceptions: %s", strings.Join(messages, "; "))
+	}
+	if resp.ResultTable == nil {
+		return nil, errors.New("broker returned no result table")
+	}
+	return resp.ResultTable, nil
+}
+
+// recordMetric appends a metric with one data point per row of table. The metric is
+// left out when no row has a valid value.
+func (s *queryScraper) recordMetric(metrics pdata.MetricSlice, now pdata.Timestamp, cfg MetricConfig, table *resultTable) error {
+	columns := make(map[string]int, len(table.DataSchema.ColumnNames))
+	for i, name := range table.DataSchema.ColumnNames {
+		columns[name] = i
+	}
+	valueIndex, ok := columns[cfg.ValueColumn]
+	if !ok {
+		return fmt.Errorf("value column %q of metric %s is not in the result", cfg.ValueColumn, cfg.MetricName)
+	}
+	attributeIndexes := make([]int, len(cfg.AttributeColumns))
+	for i, column := range cfg.AttributeColumns {
+		if attributeIndexes[i], ok = columns[column]; !ok {
+			return fmt.Errorf("attribute column %q of metric %s is not in the result", column, cfg.MetricName)
+		}
+	}
+
+	metric := pdata.NewMetric()
+	metric.SetName(cfg.MetricName)
+	metric.SetDescription(cfg.Description)
+	metric.SetUnit(cfg.Unit)
+	var dps pdata.NumberDataPointSlice
+	if cfg.DataType == MetricDataTypeSum {
+		metric.SetDataType(pdata.MetricDataTypeSum)
+		metric.Sum().SetIsMonotonic(cfg.Monotonic)
+		metric.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		dps = metric.Sum().DataPoints()
+	} else {
+		metric.SetDataType(pdata.MetricDataTypeGauge)
+		dps = metric.Gauge().DataPoints()
+	}
+
+	var err error
+	for _, row := range table.Rows {
+		if len(row) != len(table.DataSchema.ColumnNames) {
+			err = fmt.Errorf("result row of metric %s has %d columns, expected %d", cfg.MetricName, len(row), len(table.DataSchema.ColumnNames))
+			continue
+		}
+		value := cellString(row[valueIndex])
+		if value == "" {
+			// Aggregations over no rows come back as null.
+			continue
+		}
+		dp := pdata.NewNumberDataPoint()
+		if cfg.ValueType == MetricValueTypeInt {
+			v, ok := parseInt(value)
+			if !ok {
+				err = fmt.Errorf("value %q of metric %s is not an integer", value, cfg.MetricName)
+				continue
+			}
+			dp.SetIntVal(v)
+		} else {
+			v, parseErr := strconv.ParseFloat(value, 64)
+			if parseErr != nil {
+				err = fmt.Errorf("value %q of metric %s is not a number", value, cfg.MetricName)
+				continue
+			}
+			dp.SetDoubleVal(v)
+		}
+		if cfg.DataType == MetricDataTypeSum {
+			dp.SetStartTimestamp(s.startTime)
+		}
+		dp.SetTimestamp(now)
+		for i, column := range cfg.AttributeColumns {
+			dp.Attributes().InsertString(column, cellString(row[attributeIndexes[i]]))
+		}
+		dp.MoveTo(dps.AppendEmpty())
+	}
+	if dps.Len() > 0 {
+		metric.MoveTo(metrics.AppendEmpty())
+	}
+	return err
+}
+
+// parseInt parses an integer value. Pinot returns the results of some aggregations,
+// such as SUM, as doubles even over integer columns, so integral doubles like 42.0
+// are accepted too.
+func parseInt(value string) (int64, bool) {
+	if v, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return v, true
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, false
+	}
+	return int64(f), true
+}
+
+// cellString returns the text of a result cell: strings unquoted, null as an empty string, anything else as is.
+func cellString(cell json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(cell, &s); err == nil {
+		return s
+	}
+	if string(cell) == "null" {
+		return ""
+	}
+	return string(cell)
+}
@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinotqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pinotqueryreceiver"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+func newMockBroker(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/query/sql", r.URL.Path)
+		var req map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if req["sql"] == "SELECT broken" {
+			_, _ = w.Write([]byte(`{"exceptions":[{"errorCode":150,"message":"SQLParsingError"}]}`))
+			return
+		}
+		body, err := ioutil.ReadFile(filepath.Join("testdata", "response.json"))
+		require.NoError(t, err)
+		_, _ = w.Write(body)
+	}))
+}
+
+func TestScrape(t *testing.T) {
+	broker := newMockBroker(t)
+	defer broker.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = broker.URL
+	cfg.Queries = []Query{{
+		SQL: "SELECT serviceName, COUNT(*) AS errors, PERCENTILE(durationNano, 99) AS p99 FROM t GROUP BY serviceName",
+		Metrics: []MetricConfig{
+			{MetricName: "errors", ValueColumn: "errors", AttributeColumns: []string{"serviceName"}, ValueType: MetricValueTypeInt, DataType: MetricDataTypeSum, Monotonic: true},
+			{MetricName: "p99", ValueColumn: "p99", AttributeColumns: []string{"serviceName"}, Unit: "ns"},
+		},
+	}}
+	require.NoError(t, cfg.Validate())
+
+	scraper := newQueryScraper(componenttest.NewNopTelemetrySettings(), cfg)
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+
+	errorsMetric := metrics.At(0)
+	assert.Equal(t, "errors", errorsMetric.Name())
+	require.Equal(t, pdata.MetricDataTypeSum, errorsMetric.DataType())
+	assert.True(t, errorsMetric.Sum().IsMonotonic())
+	dps := errorsMetric.Sum().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	assert.Equal(t, int64(12), dps.At(0).IntVal())
+	serviceName, _ := dps.At(0).Attributes().Get("serviceName")
+	assert.Equal(t, "frontend", serviceName.StringVal())
+	assert.Equal(t, int64(3), dps.At(1).IntVal())
+
+	p99 := metrics.At(1)
+	assert.Equal(t, "ns", p99.Unit())
+	require.Equal(t, pdata.MetricDataTypeGauge, p99.DataType())
+	// The null value of cart is skipped.
+	require.Equal(t, 1, p99.Gauge().DataPoints().Len())
+	assert.Equal(t, 250.5, p99.Gauge().DataPoints().At(0).DoubleVal())
+}
+
+func TestScrapeErrors(t *testing.T) {
+	t.Run("no client", func(t *testing.T) {
+		scraper := newQueryScraper(componenttest.NewNopTelemetrySettings(), createDefaultConfig().(*Config))
+
+		_, err := scraper.scrape(context.Background())
+		require.Equal(t, errors.New("no client available"), err)
+	})
+
+	t.Run("failing query and missing column", func(t *testing.T) {
+		broker := newMockBroker(t)
+		defer broker.Close()
+
+		cfg := createDefaultConfig().(*Config)
+		cfg.Endpoint = broker.URL
+		cfg.Queries = []Query{
+			{SQL: "SELECT broken", Metrics: []MetricConfig{{MetricName: "broken", ValueColumn: "c"}}},
+			{SQL: "SELECT errors FROM t", Metrics: []MetricConfig{
+				{MetricName: "missing", ValueColumn: "missing"},
+				{MetricName: "errors", ValueColumn: "errors"},
+			}},
+		}
+		scraper := newQueryScraper(componenttest.NewNopTelemetrySettings(), cfg)
+		require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+		md, err := scraper.scrape(context.Background())
+		require.Error(t, err)
+		assert.True(t, scrapererror.IsPartialScrapeError(err))
+		assert.Contains(t, err.Error(), "150: SQLParsingError")
+		assert.Equal(t, 1, md.MetricCount())
+	})
+}
+
+func TestStart(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPClientSettings.TLSSetting.CAFile = "/non/existent"
+
+	scraper := newQueryScraper(componenttest.NewNopTelemetrySettings(), cfg)
+	require.Error(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+}
+
+func TestRecordMetric(t *testing.T) {
+	table := &resultTable{}
+	table.DataSchema.ColumnNames = []string{"serviceName", "total", "missing"}
+	table.Rows = [][]json.RawMessage{
+		{json.RawMessage(`"frontend"`), json.RawMessage(`42.0`), json.RawMessage(`null`)},
+		{json.RawMessage(`"cart"`), json.RawMessage(`7`), json.RawMessage(`null`)},
+	}
+	scraper := newQueryScraper(componenttest.NewNopTelemetrySettings(), createDefaultConfig().(*Config))
+	metrics := pdata.NewMetricSlice()
+	now := pdata.NewTimestampFromTime(time.Now())
+
+	// Integral doubles are accepted as integers.
+	require.NoError(t, scraper.recordMetric(metrics, now, MetricConfig{MetricName: "total", ValueColumn: "total", ValueType: MetricValueTypeInt}, table))
+	require.Equal(t, 1, metrics.Len())
+	dps := metrics.At(0).Gauge().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	assert.Equal(t, int64(42), dps.At(0).IntVal())
+	assert.Equal(t, int64(7), dps.At(1).IntVal())
+
+	// A metric without any valid row is left out.
+	require.NoError(t, scraper.recordMetric(metrics, now, MetricConfig{MetricName: "missing", ValueColumn: "missing"}, table))
+	assert.Equal(t, 1, metrics.Len())
+}
+
+func TestParseInt(t *testing.T) {
+	for value, expected := range map[string]int64{"42": 42, "42.0": 42, "-3": -3, "1e3": 1000} {
+		v, ok := parseInt(value)
+		assert.True(t, ok, value)
+		assert.Equal(t, expected, v, value)
+	}
+	for _, value := range []string{"42.5", "abc", "1e30", "NaN"} {
+		_, ok := parseInt(value)
+		assert.False(t, ok, value)
+	}
+}
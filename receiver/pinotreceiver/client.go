@@ -0,0 +1,192 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinotreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pinotreceiver"
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pinot"
+)
+
+// client defines the calls the scraper makes against a Pinot cluster.
+type client interface {
+	ListTables(ctx context.Context) ([]string, error)
+	GetTableSize(ctx context.Context, table string) (*tableSize, error)
+	GetSegmentCounts(ctx context.Context, table string) (map[string]int64, error)
+	GetIngestionLag(ctx context.Context, table string) (*ingestionLag, error)
+	GetStreamTopic(ctx context.Context, table string) (string, error)
+	GetQueryLatency(ctx context.Context, table string) (int64, error)
+}
+
+// tableSize is the subset of the controller's /tables/{table}/size response used by the scraper.
+type tableSize struct {
+	OfflineSegments  *segmentsSize `json:"offlineSegments"`
+	RealtimeSegments *segmentsSize `json:"realtimeSegments"`
+}
+
+type segmentsSize struct {
+	ReportedSizeInBytes int64 `json:"reportedSizeInBytes"`
+}
+
+// ingestionLag holds the largest lag reported across all consuming segments of a table.
+type ingestionLag struct {
+	Records           int64
+	AvailabilityLagMs int64
+	// PartitionRecords is the largest record lag of each stream partition.
+	PartitionRecords map[string]int64
+}
+
+type consumingSegmentsInfo struct {
+	SegmentToConsumingInfo map[string][]consumingInfo `json:"_segmentToConsumingInfoMap"`
+}
+
+type consumingInfo struct {
+	PartitionOffsetInfo struct {
+		RecordsLagMap        map[string]string `json:"recordsLagMap"`
+		AvailabilityLagMsMap map[string]string `json:"availabilityLagMsMap"`
+	} `json:"partitionOffsetInfo"`
+}
+
+// streamTopicKey is the stream config property holding the Kafka topic of a realtime table.
+const streamTopicKey = "stream.kafka.topic.name"
+
+var _ client = (*pinotClient)(nil)
+
+type pinotClient struct {
+	controller *pinot.Client
+	broker     *pinot.Client
+}
+
+// newPinotClient creates a new client to make requests for the Pinot receiver.
+func newPinotClient(cfg *Config, host component.Host, settings component.TelemetrySettings) (client, error) {
+	httpClient, err := cfg.ToClient(host.GetExtensions(), settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP Client: %w", err)
+	}
+
+	return &pinotClient{
+		controller: pinot.NewClient(cfg.Endpoint, httpClient, cfg.Retry, settings.Logger),
+		broker:     pinot.NewClient(cfg.BrokerEndpoint, httpClient, cfg.Retry, settings.Logger),
+	}, nil
+}
+
+// ListTables returns the names of all tables known to the controller.
+func (c *pinotClient) ListTables(ctx context.Context) ([]string, error) {
+	return c.controller.ListTables(ctx)
+}
+
+// GetTableSize returns the reported size of the offline and realtime segments of a table.
+func (c *pinotClient) GetTableSize(ctx context.Context, table string) (*tableSize, error) {
+	var size tableSize
+	if err := c.controller.Get(ctx, fmt.Sprintf("/tables/%s/size?detailed=false", url.PathEscape(table)), &size); err != nil {
+		return nil, err
+	}
+	return &size, nil
+}
+
+// GetSegmentCounts returns the number of segments of a table keyed by table type.
+func (c *pinotClient) GetSegmentCounts(ctx context.Context, table string) (map[string]int64, error) {
+	// The controller answers with one object per table type, e.g. [{"OFFLINE":[...]},{"REALTIME":[...]}].
+	var resp []map[string][]string
+	if err := c.controller.Get(ctx, "/segments/"+url.PathEscape(table), &resp); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64)
+	for _, byType := range resp {
+		for tableType, segments := range byType {
+			counts[strings.ToLower(tableType)] += int64(len(segments))
+		}
+	}
+	return counts, nil
+}
+
+// GetIngestionLag returns the largest record and availability lag of the consuming segments of a realtime table.
+func (c *pinotClient) GetIngestionLag(ctx context.Context, table string) (*ingestionLag, error) {
+	var info consumingSegmentsInfo
+	if err := c.controller.Get(ctx, fmt.Sprintf("/tables/%s/consumingSegmentsInfo", url.PathEscape(table)), &info); err != nil {
+		return nil, err
+	}
+
+	lag := &ingestionLag{PartitionRecords: make(map[string]int64)}
+	for _, replicas := range info.SegmentToConsumingInfo {
+		for _, replica := range replicas {
+			lag.Records = maxLag(lag.Records, replica.PartitionOffsetInfo.RecordsLagMap)
+			lag.AvailabilityLagMs = maxLag(lag.AvailabilityLagMs, replica.PartitionOffsetInfo.AvailabilityLagMsMap)
+			for partition, s := range replica.PartitionOffsetInfo.RecordsLagMap {
+				if v, ok := parseLag(s); ok && v >= lag.PartitionRecords[partition] {
+					lag.PartitionRecords[partition] = v
+				}
+			}
+		}
+	}
+	return lag, nil
+}
+
+// GetStreamTopic returns the name of the stream topic a realtime table consumes from.
+func (c *pinotClient) GetStreamTopic(ctx context.Context, table string) (string, error) {
+	var resp struct {
+		Realtime struct {
+			TableIndexConfig struct {
+				StreamConfigs map[string]string `json:"streamConfigs"`
+			} `json:"tableIndexConfig"`
+		} `json:"REALTIME"`
+	}
+	if err := c.controller.GetTable(ctx, table, "realtime", &resp); err != nil {
+		return "", err
+	}
+	return resp.Realtime.TableIndexConfig.StreamConfigs[streamTopicKey], nil
+}
+
+// GetQueryLatency issues a count query for the table against the broker and returns the time it took in milliseconds.
+func (c *pinotClient) GetQueryLatency(ctx context.Context, table string) (int64, error) {
+	var resp struct {
+		TimeUsedMs int64 `json:"timeUsedMs"`
+	}
+	query := map[string]string{"sql": "SELECT COUNT(*) FROM " + quoteIdentifier(table)}
+	if err := c.broker.Post(ctx, "/query/sql", query, &resp); err != nil {
+		return 0, err
+	}
+	return resp.TimeUsedMs, nil
+}
+
+// quoteIdentifier quotes a table name for Pinot SQL. Listed tables don't go
+// through config validation, so embedded quotes are escaped by doubling them.
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// maxLag returns the largest of current and the per-partition lags. Pinot
+// reports lags as strings and uses "UNKNOWN" when it cannot compute them;
+// such values are skipped.
+func maxLag(current int64, lags map[string]string) int64 {
+	for _, s := range lags {
+		if v, ok := parseLag(s); ok && v > current {
+			current = v
+		}
+	}
+	return current
+}
+
+func parseLag(s string) (int64, bool) {
+	v, err := strconv.ParseInt(s, 10, 64)
+	return v, err == nil
+}
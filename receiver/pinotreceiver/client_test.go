@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinotreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pinotreceiver"
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func TestGetQueryLatencyQuotesTable(t *testing.T) {
+	var sql string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var query map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&query))
+		sql = query["sql"]
+		_, _ = w.Write([]byte(`{"timeUsedMs": 7}`))
+	}))
+	defer srv.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.BrokerEndpoint = srv.URL
+	c, err := newPinotClient(cfg, componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	latency, err := c.GetQueryLatency(context.Background(), `signoz"; DROP TABLE signoz_metrics; --`)
+	require.NoError(t, err)
+	assert.EqualValues(t, 7, latency)
+	assert.Equal(t, `SELECT COUNT(*) FROM "signoz""; DROP TABLE signoz_metrics; --"`, sql)
+}
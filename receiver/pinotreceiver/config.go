@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinotreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pinotreceiver"
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/pinot"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pinotreceiver/internal/metadata"
+)
+
+const defaultEndpoint = "http://localhost:9000"
+
+// tableNamePattern matches the table names accepted in the configuration.
+var tableNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Config defines the configuration for the Pinot receiver.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+	// HTTPClientSettings points at the Pinot controller.
+	confighttp.HTTPClientSettings `mapstructure:",squash"`
+
+	// BrokerEndpoint is the address of a Pinot broker. When set, a count query
+	// is issued against every scraped table to measure query latency.
+	BrokerEndpoint string `mapstructure:"broker_endpoint"`
+
+	// Tables limits scraping to the given tables. All tables known to the
+	// controller are scraped when empty.
+	Tables []string `mapstructure:"tables"`
+
+	// Retry defines how failed requests against the controller and broker are retried.
+	Retry pinot.RetrySettings `mapstructure:"retry_on_failure"`
+
+	// Metrics allows customizing scraped metrics representation.
+	Metrics metadata.MetricsSettings `mapstructure:"metrics"`
+}
+
+// Validate validates the endpoints and tables of the receiver configuration.
+func (cfg *Config) Validate() error {
+	var err error
+	if _, parseErr := url.Parse(cfg.Endpoint); parseErr != nil {
+		err = multierr.Append(err, fmt.Errorf("\"endpoint\" %q must be in the form of <scheme>://<hostname>:<port>: %w", cfg.Endpoint, parseErr))
+	}
+	if cfg.BrokerEndpoint != "" {
+		if _, parseErr := url.Parse(cfg.BrokerEndpoint); parseErr != nil {
+			err = multierr.Append(err, fmt.Errorf("\"broker_endpoint\" %q must be in the form of <scheme>://<hostname>:<port>: %w", cfg.BrokerEndpoint, parseErr))
+		}
+	}
+	for _, table := range cfg.Tables {
+		if !tableNamePattern.MatchString(table) {
+			err = multierr.Append(err, fmt.Errorf("\"tables\" entry %q must only contain letters, digits, underscores and hyphens", table))
+		}
+	}
+	return err
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinotreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pinotreceiver"
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/service/servicetest"
+	"go.uber.org/multierr"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := servicetest.LoadConfigAndValidate(filepath.Join("testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.Len(t, cfg.Receivers, 1)
+
+	r := cfg.Receivers[config.NewComponentID(typeStr)].(*Config)
+	require.Equal(t, "http://localhost:9000", r.Endpoint)
+	require.Equal(t, "http://localhost:8099", r.BrokerEndpoint)
+	require.Equal(t, []string{"signoz_metrics"}, r.Tables)
+	require.Equal(t, 30*time.Second, r.CollectionInterval)
+}
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		cfg         *Config
+		expectedErr error
+	}{
+		{
+			desc: "invalid endpoint",
+			cfg: &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "http://localhost :9000",
+				},
+			},
+			expectedErr: errors.New(`"endpoint" "http://localhost :9000" must be in the form of <scheme>://<hostname>:<port>: parse "http://localhost :9000": invalid character " " in host name`),
+		},
+		{
+			desc: "invalid endpoint and broker endpoint",
+			cfg: &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "http://localhost :9000",
+				},
+				BrokerEndpoint: "http://localhost :8099",
+			},
+			expectedErr: multierr.Combine(
+				errors.New(`"endpoint" "http://localhost :9000" must be in the form of <scheme>://<hostname>:<port>: parse "http://localhost :9000": invalid character " " in host name`),
+				errors.New(`"broker_endpoint" "http://localhost :8099" must be in the form of <scheme>://<hostname>:<port>: parse "http://localhost :8099": invalid character " " in host name`),
+			),
+		},
+		{
+			desc: "invalid table",
+			cfg: &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: defaultEndpoint,
+				},
+				Tables: []string{"signoz_metrics", "signoz_metrics; DROP TABLE signoz_metrics"},
+			},
+			expectedErr: errors.New(`"tables" entry "signoz_metrics; DROP TABLE signoz_metrics" must only contain letters, digits, underscores and hyphens`),
+		},
+		{
+			desc: "valid config without broker",
+			cfg: &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: defaultEndpoint,
+				},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			actualErr := tc.cfg.Validate()
+			if tc.expectedErr != nil {
+				require.EqualError(t, actualErr, tc.expectedErr.Error())
+			} else {
+				require.NoError(t, actualErr)
+			}
+		})
+	}
+}
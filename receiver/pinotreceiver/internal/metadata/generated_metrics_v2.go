@@ -0,0 +1,479 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// MetricSettings provides common settings for a particular metric.
+type MetricSettings struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// MetricsSettings provides settings for pinotreceiver metrics.
+type MetricsSettings struct {
+	PinotQueryLatency                  MetricSettings `mapstructure:"pinot.query.latency"`
+	PinotTableIngestionAvailabilityLag MetricSettings `mapstructure:"pinot.table.ingestion.availability_lag"`
+	PinotTableIngestionLag             MetricSettings `mapstructure:"pinot.table.ingestion.lag"`
+	PinotTablePartitionIngestionLag    MetricSettings `mapstructure:"pinot.table.partition.ingestion.lag"`
+	PinotTableSegmentCount             MetricSettings `mapstructure:"pinot.table.segment.count"`
+	PinotTableSize                     MetricSettings `mapstructure:"pinot.table.size"`
+}
+
+func DefaultMetricsSettings() MetricsSettings {
+	return MetricsSettings{
+		PinotQueryLatency: MetricSettings{
+			Enabled: true,
+		},
+		PinotTableIngestionAvailabilityLag: MetricSettings{
+			Enabled: true,
+		},
+		PinotTableIngestionLag: MetricSettings{
+			Enabled: true,
+		},
+		PinotTablePartitionIngestionLag: MetricSettings{
+			Enabled: true,
+		},
+		PinotTableSegmentCount: MetricSettings{
+			Enabled: true,
+		},
+		PinotTableSize: MetricSettings{
+			Enabled: true,
+		},
+	}
+}
+
+type metricPinotQueryLatency struct {
+	data     pdata.Metric   // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills pinot.query.latency metric with initial data.
+func (m *metricPinotQueryLatency) init() {
+	m.data.SetName("pinot.query.latency")
+	m.data.SetDescription("The time the broker took to answer a count query on the table. Only collected when broker_endpoint is set.")
+	m.data.SetUnit("ms")
+	m.data.SetDataType(pdata.MetricDataTypeGauge)
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPinotQueryLatency) recordDataPoint(start pdata.Timestamp, ts pdata.Timestamp, val int64, tableAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntVal(val)
+	dp.Attributes().Insert(A.Table, pdata.NewAttributeValueString(tableAttributeValue))
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPinotQueryLatency) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPinotQueryLatency) emit(metrics pdata.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPinotQueryLatency(settings MetricSettings) metricPinotQueryLatency {
+	m := metricPinotQueryLatency{settings: settings}
+	if settings.Enabled {
+		m.data = pdata.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPinotTableIngestionAvailabilityLag struct {
+	data     pdata.Metric   // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills pinot.table.ingestion.availability_lag metric with initial data.
+func (m *metricPinotTableIngestionAvailabilityLag) init() {
+	m.data.SetName("pinot.table.ingestion.availability_lag")
+	m.data.SetDescription("The largest delay between a record being produced and it being queryable in the table.")
+	m.data.SetUnit("ms")
+	m.data.SetDataType(pdata.MetricDataTypeGauge)
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPinotTableIngestionAvailabilityLag) recordDataPoint(start pdata.Timestamp, ts pdata.Timestamp, val int64, tableAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntVal(val)
+	dp.Attributes().Insert(A.Table, pdata.NewAttributeValueString(tableAttributeValue))
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPinotTableIngestionAvailabilityLag) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPinotTableIngestionAvailabilityLag) emit(metrics pdata.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPinotTableIngestionAvailabilityLag(settings MetricSettings) metricPinotTableIngestionAvailabilityLag {
+	m := metricPinotTableIngestionAvailabilityLag{settings: settings}
+	if settings.Enabled {
+		m.data = pdata.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPinotTableIngestionLag struct {
+	data     pdata.Metric   // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills pinot.table.ingestion.lag metric with initial data.
+func (m *metricPinotTableIngestionLag) init() {
+	m.data.SetName("pinot.table.ingestion.lag")
+	m.data.SetDescription("The largest number of records the consuming segments of the table are behind the stream.")
+	m.data.SetUnit("{records}")
+	m.data.SetDataType(pdata.MetricDataTypeGauge)
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPinotTableIngestionLag) recordDataPoint(start pdata.Timestamp, ts pdata.Timestamp, val int64, tableAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntVal(val)
+	dp.Attributes().Insert(A.Table, pdata.NewAttributeValueString(tableAttributeValue))
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPinotTableIngestionLag) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPinotTableIngestionLag) emit(metrics pdata.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPinotTableIngestionLag(settings MetricSettings) metricPinotTableIngestionLag {
+	m := metricPinotTableIngestionLag{settings: settings}
+	if settings.Enabled {
+		m.data = pdata.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPinotTablePartitionIngestionLag struct {
+	data     pdata.Metric   // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills pinot.table.partition.ingestion.lag metric with initial data.
+func (m *metricPinotTablePartitionIngestionLag) init() {
+	m.data.SetName("pinot.table.partition.ingestion.lag")
+	m.data.SetDescription("The number of records the table is behind the stream on a partition of its topic.")
+	m.data.SetUnit("{records}")
+	m.data.SetDataType(pdata.MetricDataTypeGauge)
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPinotTablePartitionIngestionLag) recordDataPoint(start pdata.Timestamp, ts pdata.Timestamp, val int64, tableAttributeValue string, topicAttributeValue string, partitionAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntVal(val)
+	dp.Attributes().Insert(A.Table, pdata.NewAttributeValueString(tableAttributeValue))
+	dp.Attributes().Insert(A.Topic, pdata.NewAttributeValueString(topicAttributeValue))
+	dp.Attributes().Insert(A.Partition, pdata.NewAttributeValueString(partitionAttributeValue))
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPinotTablePartitionIngestionLag) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPinotTablePartitionIngestionLag) emit(metrics pdata.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPinotTablePartitionIngestionLag(settings MetricSettings) metricPinotTablePartitionIngestionLag {
+	m := metricPinotTablePartitionIngestionLag{settings: settings}
+	if settings.Enabled {
+		m.data = pdata.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPinotTableSegmentCount struct {
+	data     pdata.Metric   // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills pinot.table.segment.count metric with initial data.
+func (m *metricPinotTableSegmentCount) init() {
+	m.data.SetName("pinot.table.segment.count")
+	m.data.SetDescription("The number of segments of the table.")
+	m.data.SetUnit("{segments}")
+	m.data.SetDataType(pdata.MetricDataTypeSum)
+	m.data.Sum().SetIsMonotonic(false)
+	m.data.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPinotTableSegmentCount) recordDataPoint(start pdata.Timestamp, ts pdata.Timestamp, val int64, tableAttributeValue string, tableTypeAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntVal(val)
+	dp.Attributes().Insert(A.Table, pdata.NewAttributeValueString(tableAttributeValue))
+	dp.Attributes().Insert(A.TableType, pdata.NewAttributeValueString(tableTypeAttributeValue))
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPinotTableSegmentCount) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPinotTableSegmentCount) emit(metrics pdata.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPinotTableSegmentCount(settings MetricSettings) metricPinotTableSegmentCount {
+	m := metricPinotTableSegmentCount{settings: settings}
+	if settings.Enabled {
+		m.data = pdata.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricPinotTableSize struct {
+	data     pdata.Metric   // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills pinot.table.size metric with initial data.
+func (m *metricPinotTableSize) init() {
+	m.data.SetName("pinot.table.size")
+	m.data.SetDescription("The size of the table segments reported by the servers.")
+	m.data.SetUnit("By")
+	m.data.SetDataType(pdata.MetricDataTypeSum)
+	m.data.Sum().SetIsMonotonic(false)
+	m.data.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricPinotTableSize) recordDataPoint(start pdata.Timestamp, ts pdata.Timestamp, val int64, tableAttributeValue string, tableTypeAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntVal(val)
+	dp.Attributes().Insert(A.Table, pdata.NewAttributeValueString(tableAttributeValue))
+	dp.Attributes().Insert(A.TableType, pdata.NewAttributeValueString(tableTypeAttributeValue))
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricPinotTableSize) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricPinotTableSize) emit(metrics pdata.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricPinotTableSize(settings MetricSettings) metricPinotTableSize {
+	m := metricPinotTableSize{settings: settings}
+	if settings.Enabled {
+		m.data = pdata.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+// MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
+// required to produce metric representation defined in metadata and user settings.
+type MetricsBuilder struct {
+	startTime                                pdata.Timestamp
+	metricPinotQueryLatency                  metricPinotQueryLatency
+	metricPinotTableIngestionAvailabilityLag metricPinotTableIngestionAvailabilityLag
+	metricPinotTableIngestionLag             metricPinotTableIngestionLag
+	metricPinotTablePartitionIngestionLag    metricPinotTablePartitionIngestionLag
+	metricPinotTableSegmentCount             metricPinotTableSegmentCount
+	metricPinotTableSize                     metricPinotTableSize
+}
+
+// metricBuilderOption applies changes to default metrics builder.
+type metricBuilderOption func(*MetricsBuilder)
+
+// WithStartTime sets startTime on the metrics builder.
+func WithStartTime(startTime pdata.Timestamp) metricBuilderOption {
+	return func(mb *MetricsBuilder) {
+		mb.startTime = startTime
+	}
+}
+
+func NewMetricsBuilder(settings MetricsSettings, options ...metricBuilderOption) *MetricsBuilder {
+	mb := &MetricsBuilder{
+		startTime:                                pdata.NewTimestampFromTime(time.Now()),
+		metricPinotQueryLatency:                  newMetricPinotQueryLatency(settings.PinotQueryLatency),
+		metricPinotTableIngestionAvailabilityLag: newMetricPinotTableIngestionAvailabilityLag(settings.PinotTableIngestionAvailabilityLag),
+		metricPinotTableIngestionLag:             newMetricPinotTableIngestionLag(settings.PinotTableIngestionLag),
+		metricPinotTablePartitionIngestionLag:    newMetricPinotTablePartitionIngestionLag(settings.PinotTablePartitionIngestionLag),
+		metricPinotTableSegmentCount:             newMetricPinotTableSegmentCount(settings.PinotTableSegmentCount),
+		metricPinotTableSize:                     newMetricPinotTableSize(settings.PinotTableSize),
+	}
+	for _, op := range options {
+		op(mb)
+	}
+	return mb
+}
+
+// Emit appends generated metrics to a pdata.MetricsSlice and updates the internal state to be ready for recording
+// another set of data points. This function will be doing all transformations required to produce metric representation
+// defined in metadata and user settings, e.g. delta/cumulative translation.
+func (mb *MetricsBuilder) Emit(metrics pdata.MetricSlice) {
+	mb.metricPinotQueryLatency.emit(metrics)
+	mb.metricPinotTableIngestionAvailabilityLag.emit(metrics)
+	mb.metricPinotTableIngestionLag.emit(metrics)
+	mb.metricPinotTablePartitionIngestionLag.emit(metrics)
+	mb.metricPinotTableSegmentCount.emit(metrics)
+	mb.metricPinotTableSize.emit(metrics)
+}
+
+// RecordPinotQueryLatencyDataPoint adds a data point to pinot.query.latency metric.
+func (mb *MetricsBuilder) RecordPinotQueryLatencyDataPoint(ts pdata.Timestamp, val int64, tableAttributeValue string) {
+	mb.metricPinotQueryLatency.recordDataPoint(mb.startTime, ts, val, tableAttributeValue)
+}
+
+// RecordPinotTableIngestionAvailabilityLagDataPoint adds a data point to pinot.table.ingestion.availability_lag metric.
+func (mb *MetricsBuilder) RecordPinotTableIngestionAvailabilityLagDataPoint(ts pdata.Timestamp, val int64, tableAttributeValue string) {
+	mb.metricPinotTableIngestionAvailabilityLag.recordDataPoint(mb.startTime, ts, val, tableAttributeValue)
+}
+
+// RecordPinotTableIngestionLagDataPoint adds a data point to pinot.table.ingestion.lag metric.
+func (mb *MetricsBuilder) RecordPinotTableIngestionLagDataPoint(ts pdata.Timestamp, val int64, tableAttributeValue string) {
+	mb.metricPinotTableIngestionLag.recordDataPoint(mb.startTime, ts, val, tableAttributeValue)
+}
+
+// RecordPinotTablePartitionIngestionLagDataPoint adds a data point to pinot.table.partition.ingestion.lag metric.
+func (mb *MetricsBuilder) RecordPinotTablePartitionIngestionLagDataPoint(ts pdata.Timestamp, val int64, tableAttributeValue string, topicAttributeValue string, partitionAttributeValue string) {
+	mb.metricPinotTablePartitionIngestionLag.recordDataPoint(mb.startTime, ts, val, tableAttributeValue, topicAttributeValue, partitionAttributeValue)
+}
+
+// RecordPinotTableSegmentCountDataPoint adds a data point to pinot.table.segment.count metric.
+func (mb *MetricsBuilder) RecordPinotTableSegmentCountDataPoint(ts pdata.Timestamp, val int64, tableAttributeValue string, tableTypeAttributeValue string) {
+	mb.metricPinotTableSegmentCount.recordDataPoint(mb.startTime, ts, val, tableAttributeValue, tableTypeAttributeValue)
+}
+
+// RecordPinotTableSizeDataPoint adds a data point to pinot.table.size metric.
+func (mb *MetricsBuilder) RecordPinotTableSizeDataPoint(ts pdata.Timestamp, val int64, tableAttributeValue string, tableTypeAttributeValue string) {
+	mb.metricPinotTableSize.recordDataPoint(mb.startTime, ts, val, tableAttributeValue, tableTypeAttributeValue)
+}
+
+// Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
+// and metrics builder should update its startTime and reset it's internal state accordingly.
+func (mb *MetricsBuilder) Reset(options ...metricBuilderOption) {
+	mb.startTime = pdata.NewTimestampFromTime(time.Now())
+	for _, op := range options {
+		op(mb)
+	}
+}
+
+// Attributes contains the possible metric attributes that can be used.
+var Attributes = struct {
+	// Partition (The stream partition.)
+	Partition string
+	// Table (The name of the Pinot table.)
+	Table string
+	// TableType (The type of the Pinot table.)
+	TableType string
+	// Topic (The stream topic a realtime table consumes from.)
+	Topic string
+}{
+	"partition",
+	"table",
+	"table_type",
+	"topic",
+}
+
+// A is an alias for Attributes.
+var A = Attributes
+
+// AttributeTableType are the possible values that the attribute "table_type" can have.
+var AttributeTableType = struct {
+	Offline  string
+	Realtime string
+}{
+	"offline",
+	"realtime",
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinotreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pinotreceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pinotreceiver/internal/metadata"
+)
+
+type pinotScraper struct {
+	client   client
+	config   *Config
+	settings component.TelemetrySettings
+	mb       *metadata.MetricsBuilder
+}
+
+func newPinotScraper(settings component.TelemetrySettings, config *Config) *pinotScraper {
+	return &pinotScraper{
+		settings: settings,
+		config:   config,
+		mb:       metadata.NewMetricsBuilder(config.Metrics),
+	}
+}
+
+func (p *pinotScraper) start(_ context.Context, host component.Host) error {
+	httpClient, err := newPinotClient(p.config, host, p.settings)
+	if err != nil {
+		return fmt.Errorf("failed to start: %w", err)
+	}
+	p.client = httpClient
+	return nil
+}
+
+func (p *pinotScraper) scrape(ctx context.Context) (pdata.Metrics, error) {
+	if p.client == nil {
+		return pdata.NewMetrics(), errors.New("no client available")
+	}
+
+	tables := p.config.Tables
+	if len(tables) == 0 {
+		var err error
+		tables, err = p.client.ListTables(ctx)
+		if err != nil {
+			p.settings.Logger.Error("Failed to list pinot tables",
+				zap.String("endpoint", p.config.Endpoint),
+				zap.Error(err),
+			)
+			return pdata.NewMetrics(), err
+		}
+	}
+
+	now := pdata.NewTimestampFromTime(time.Now())
+	var errs scrapererror.ScrapeErrors
+	for _, table := range tables {
+		p.scrapeTable(ctx, now, table, &errs)
+	}
+
+	md := pdata.NewMetrics()
+	ilm := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty()
+	ilm.InstrumentationLibrary().SetName("otelcol/pinot")
+	p.mb.Emit(ilm.Metrics())
+	return md, errs.Combine()
+}
+
+func (p *pinotScraper) scrapeTable(ctx context.Context, now pdata.Timestamp, table string, errs *scrapererror.ScrapeErrors) {
+	size, err := p.client.GetTableSize(ctx, table)
+	if err != nil {
+		errs.AddPartial(2, fmt.Errorf("failed to get size of table %s: %w", table, err))
+	} else {
+		if size.OfflineSegments != nil {
+			p.mb.RecordPinotTableSizeDataPoint(now, size.OfflineSegments.ReportedSizeInBytes, table, metadata.AttributeTableType.Offline)
+		}
+		if size.RealtimeSegments != nil {
+			p.mb.RecordPinotTableSizeDataPoint(now, size.RealtimeSegments.ReportedSizeInBytes, table, metadata.AttributeTableType.Realtime)
+		}
+	}
+
+	counts, err := p.client.GetSegmentCounts(ctx, table)
+	if err != nil {
+		errs.AddPartial(2, fmt.Errorf("failed to get segments of table %s: %w", table, err))
+	} else {
+		for _, tableType := range []string{metadata.AttributeTableType.Offline, metadata.AttributeTableType.Realtime} {
+			if count, ok := counts[tableType]; ok {
+				p.mb.RecordPinotTableSegmentCountDataPoint(now, count, table, tableType)
+			}
+		}
+	}
+
+	// Only realtime tables have consuming segments; the controller rejects the
+	// request for offline-only tables.
+	if _, ok := counts[metadata.AttributeTableType.Realtime]; ok {
+		lag, err := p.client.GetIngestionLag(ctx, table)
+		if err != nil {
+			errs.AddPartial(2, fmt.Errorf("failed to get ingestion lag of table %s: %w", table, err))
+		} else {
+			p.mb.RecordPinotTableIngestionLagDataPoint(now, lag.Records, table)
+			p.mb.RecordPinotTableIngestionAvailabilityLagDataPoint(now, lag.AvailabilityLagMs, table)
+			p.recordPartitionLag(ctx, now, table, lag, errs)
+		}
+	}
+
+	if p.config.BrokerEndpoint != "" {
+		latency, err := p.client.GetQueryLatency(ctx, table)
+		if err != nil {
+			errs.AddPartial(1, fmt.Errorf("failed to query table %s: %w", table, err))
+		} else {
+			p.mb.RecordPinotQueryLatencyDataPoint(now, latency, table)
+		}
+	}
+}
+
+// recordPartitionLag reports the record lag of every partition of the topic
+// the table consumes from, so a single partition falling behind is visible
+// even when the table-wide maximum is dominated by another one.
+func (p *pinotScraper) recordPartitionLag(ctx context.Context, now pdata.Timestamp, table string, lag *ingestionLag, errs *scrapererror.ScrapeErrors) {
+	if len(lag.PartitionRecords) == 0 {
+		return
+	}
+	topic, err := p.client.GetStreamTopic(ctx, table)
+	if err != nil {
+		errs.AddPartial(len(lag.PartitionRecords), fmt.Errorf("failed to get stream topic of table %s: %w", table, err))
+		return
+	}
+	for partition, records := range lag.PartitionRecords {
+		p.mb.RecordPinotTablePartitionIngestionLagDataPoint(now, records, table, topic, partition)
+	}
+}
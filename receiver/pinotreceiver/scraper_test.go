@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pinotreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/pinotreceiver"
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/scrapertest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/scrapertest/golden"
+)
+
+func TestScrape(t *testing.T) {
+	pinot := newMockPinot(t)
+	defer pinot.Close()
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Endpoint = pinot.URL
+	cfg.BrokerEndpoint = pinot.URL
+	require.NoError(t, cfg.Validate())
+
+	scraper := newPinotScraper(componenttest.NewNopTelemetrySettings(), cfg)
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	expectedFile := filepath.Join("testdata", "scraper", "expected.json")
+	expectedMetrics, err := golden.ReadMetrics(expectedFile)
+	require.NoError(t, err)
+
+	require.NoError(t, scrapertest.CompareMetrics(expectedMetrics, actualMetrics))
+}
+
+func TestScrapeConfiguredTables(t *testing.T) {
+	pinot := newMockPinot(t)
+	defer pinot.Close()
+
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Endpoint = pinot.URL
+	cfg.Tables = []string{"signoz_archive", "missing"}
+
+	scraper := newPinotScraper(componenttest.NewNopTelemetrySettings(), cfg)
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	md, err := scraper.scrape(context.Background())
+	require.Error(t, err)
+	require.True(t, scrapererror.IsPartialScrapeError(err))
+	// Size and segment count of the offline table are still reported.
+	require.Equal(t, 2, md.MetricCount())
+}
+
+func TestScrapeErrors(t *testing.T) {
+	t.Run("no client", func(t *testing.T) {
+		scraper := newPinotScraper(componenttest.NewNopTelemetrySettings(), createDefaultConfig().(*Config))
+
+		_, err := scraper.scrape(context.Background())
+		require.Equal(t, errors.New("no client available"), err)
+	})
+
+	t.Run("list tables fails", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		cfg := createDefaultConfig().(*Config)
+		cfg.Endpoint = server.URL
+		cfg.Retry.Enabled = false
+		scraper := newPinotScraper(componenttest.NewNopTelemetrySettings(), cfg)
+		require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+		_, err := scraper.scrape(context.Background())
+		require.Error(t, err)
+		require.False(t, scrapererror.IsPartialScrapeError(err))
+	})
+}
+
+func TestStart(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPClientSettings.TLSSetting.CAFile = "/non/existent"
+
+	scraper := newPinotScraper(componenttest.NewNopTelemetrySettings(), cfg)
+	require.Error(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+}
+
+// newMockPinot serves canned controller and broker responses from testdata/scraper.
+func newMockPinot(t *testing.T) *httptest.Server {
+	routes := map[string]string{
+		"/tables":                                      "tables.json",
+		"/tables/signoz_metrics":                       "table_signoz_metrics.json",
+		"/tables/signoz_metrics/size":                  "size_signoz_metrics.json",
+		"/tables/signoz_archive/size":                  "size_signoz_archive.json",
+		"/segments/signoz_metrics":                     "segments_signoz_metrics.json",
+		"/segments/signoz_archive":                     "segments_signoz_archive.json",
+		"/tables/signoz_metrics/consumingSegmentsInfo": "consuming_signoz_metrics.json",
+		"/query/sql":                                   "query.json",
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, ok := routes[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		body, err := ioutil.ReadFile(filepath.Join("testdata", "scraper", file))
+		require.NoError(t, err)
+		_, err = w.Write(body)
+		require.NoError(t, err)
+	}))
+}